@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/bytearena/core/common/recording"
 	"github.com/bytearena/core/common/types"
-	"github.com/bytearena/core/common/utils/vector"
+	"github.com/bytearena/core/common/utils/space"
+
+	"github.com/bytearena/ecs"
 
 	arenaserveragent "github.com/bytearena/core/arenaserver/agent"
 	containertypes "github.com/bytearena/core/arenaserver/container"
@@ -16,8 +22,7 @@ import (
 	bettererrors "github.com/xtuc/better-errors"
 )
 
-func (s *Server) RegisterAgent(agent *types.Agent, spawningPoint *vector.Vector2) {
-	agentimage := agent.Manifest.Id
+func (s *Server) RegisterAgent(agent *types.Agent, spawningPoint *space.MapVector2) {
 
 	///////////////////////////////////////////////////////////////////////////
 	// Building the agent entity (gameplay related aspects of the agent)
@@ -41,12 +46,18 @@ func (s *Server) RegisterAgent(agent *types.Agent, spawningPoint *vector.Vector2
 
 		agentSpawningPos := arenamap.Data.Starts[agentSpawnPointIndex]
 
-		vector := vector.MakeVector2(agentSpawningPos.Point.GetX(), agentSpawningPos.Point.GetY())
-		spawningPoint = &vector
+		mapPoint := agentSpawningPos.Point.ToMapVector2()
+		spawningPoint = &mapPoint
 	}
 
 	agententityid := s.game.NewEntityAgent(agent, *spawningPoint)
 
+	s.registerAgentProxy(agent, agententityid, spawningPoint)
+}
+
+func (s *Server) registerAgentProxy(agent *types.Agent, agententityid ecs.EntityID, spawningPoint *space.MapVector2) {
+	agentimage := agent.Manifest.Id
+
 	///////////////////////////////////////////////////////////////////////////
 	// Building the agent proxy (concrete link with container and communication pipe)
 	///////////////////////////////////////////////////////////////////////////
@@ -94,6 +105,7 @@ func (s *Server) ReloadAgent(agent *types.Agent) error {
 	}
 
 	// Stop and remove container
+	s.markAgentContainerStopped(container)
 	s.containerorchestrator.TearDown(container)
 	s.containerorchestrator.RemoveContainer(container)
 
@@ -105,11 +117,21 @@ func (s *Server) ReloadAgent(agent *types.Agent) error {
 	case <-waiterr: // ok, probably already removed
 	}
 
-	// Re-register it
+	return s.restartAgentContainer(agent, false)
+}
+
+// restartAgentContainer registers the agent again and starts a new container
+// for it. With keepEntity, the new container drives the entity that is already
+// in the game, which keeps its score and position.
+func (s *Server) restartAgentContainer(agent *types.Agent, keepEntity bool) error {
 	lastSpawnedPoint, _ := s.agentspawnedvector[agent.UUID]
 
 	s.gameStepMutex.Lock()
-	s.RegisterAgent(agent, lastSpawnedPoint)
+	if keepEntity {
+		s.registerAgentProxy(agent, agent.EntityID, lastSpawnedPoint)
+	} else {
+		s.RegisterAgent(agent, lastSpawnedPoint)
+	}
 	s.gameStepMutex.Unlock()
 
 	// Re-start it
@@ -126,34 +148,253 @@ func (s *Server) ReloadAgent(agent *types.Agent) error {
 	return nil
 }
 
-func (s *Server) startAgentContainer(
+// onAgentContainerExited applies the restart policy to an agent whose
+// container exited while the game was running
+func (s *Server) onAgentContainerExited(agent *types.Agent, exitCode int64) {
+	s.agentrestartsmutex.Lock()
+	restarts := s.agentrestarts[agent.EntityID]
+	shouldRestart := s.restartpolicy.ShouldRestart(exitCode, restarts)
+
+	if shouldRestart {
+		restarts++
+		s.agentrestarts[agent.EntityID] = restarts
+	}
+	s.agentrestartsmutex.Unlock()
+
+	if !shouldRestart {
+		// Do not leave an orphaned entity standing in the arena
+		s.gameStepMutex.Lock()
+		s.game.RemoveEntityAgent(agent)
+		s.gameStepMutex.Unlock()
+
+		s.Log(EventHeadsUp{"Agent " + agent.Manifest.Id + " will not be restarted (policy " + s.restartpolicy.String() + ")"})
+		return
+	}
+
+	err := s.restartAgentContainer(agent, true)
+
+	if err != nil {
+		s.Log(EventError{err})
+
+		s.gameStepMutex.Lock()
+		s.game.RemoveEntityAgent(agent)
+		s.gameStepMutex.Unlock()
+
+		return
+	}
+
+	s.Log(EventHeadsUp{fmt.Sprintf("Agent %s restarted (%d restarts)", agent.Manifest.Id, restarts)})
+
+	s.gameStepMutex.Lock()
+	s.game.NotifyAgentRestarted(agent, restarts)
+	s.gameStepMutex.Unlock()
+
+	game := s.GetGameDescription()
+
+	err = s.mqClient.Publish("game", "agentrestarted", types.NewMQMessage(
+		"arena-server",
+		"Arena Server "+s.arenaServerUUID+", game "+game.GetId()+": agent "+agent.Manifest.Id+" restarted",
+	).SetPayload(types.MQPayload{
+		"id":              game.GetId(),
+		"arenaserveruuid": s.arenaServerUUID,
+		"agent":           agent.Manifest.Id,
+		"entity":          agent.EntityID.String(),
+		"exitcode":        exitCode,
+		"restarts":        restarts,
+	}))
+
+	if err != nil {
+		s.Log(EventWarn{bettererrors.New("Failed to publish agent restart").With(bettererrors.NewFromErr(err))})
+	}
+}
+
+// markAgentContainerStopped flags a container that we stop on purpose, so
+// that its exit does not go through the restart policy
+func (s *Server) markAgentContainerStopped(container *types.AgentContainer) {
+	s.agentrestartsmutex.Lock()
+	defer s.agentrestartsmutex.Unlock()
+
+	s.stoppedcontainers[container.Containerid] = struct{}{}
+}
+
+func (s *Server) wasAgentContainerStopped(container *types.AgentContainer) bool {
+	s.agentrestartsmutex.Lock()
+	defer s.agentrestartsmutex.Unlock()
+
+	_, stopped := s.stoppedcontainers[container.Containerid]
+	delete(s.stoppedcontainers, container.Containerid)
+
+	return stopped
+}
+
+func (s *Server) getAgentByProxyUUID(id uuid.UUID) *types.Agent {
+	for _, agent := range s.GetGameDescription().GetAgents() {
+		if uuid.Equal(agent.UUID, id) {
+			return agent
+		}
+	}
+
+	return nil
+}
+
+// checkAgentsCompatibility refuses agents whose manifest does not support
+// the game mode, protocol or encoding of this game
+func (s *Server) checkAgentsCompatibility() error {
+	gameMode := types.GetGameMode(s.GetGameDescription())
+
+	for _, agent := range s.GetGameDescription().GetAgents() {
+		err := types.CheckAgentManifestCompatibility(agent.Manifest, gameMode)
+
+		if err != nil {
+			return bettererrors.
+				New("Incompatible agent").
+				SetContext("agent", agent.Manifest.Id).
+				With(err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) pullAgentImages() error {
+	s.agentimagesmutex.Lock()
+	defer s.agentimagesmutex.Unlock()
+
+	if s.agentimagespulled {
+		return nil
+	}
+
+	dockerimages := make([]string, 0)
+
+	for _, agent := range s.GetGameDescription().GetAgents() {
+		dockerimages = append(dockerimages, agent.Manifest.Id)
+	}
+
+	err := s.containerorchestrator.PullImages(dockerimages)
+
+	if err != nil {
+		return err
+	}
+
+	s.agentimagespulled = true
+
+	return nil
+}
+
+func (s *Server) createAgentContainer(
 	agentproxy arenaserveragent.AgentProxyInterface,
-) error {
+) (*types.AgentContainer, error) {
 	dockerimage := s.agentimages[agentproxy.GetProxyUUID()]
 
 	arenaHostnameForAgents, err := s.containerorchestrator.GetHost()
 
 	if err != nil {
-		return bettererrors.
+		return nil, bettererrors.
 			New("Failed to fetch arena hostname for agents").
 			With(bettererrors.NewFromErr(err))
 	}
 
-	container, err1 := s.containerorchestrator.CreateAgentContainer(
+	container, err := s.containerorchestrator.CreateAgentContainer(
 		agentproxy.GetProxyUUID(),
 		arenaHostnameForAgents,
 		s.port,
 		dockerimage,
 	)
 
-	if err1 != nil {
-		return bettererrors.
+	if err != nil {
+		return nil, bettererrors.
 			New("Failed to create docker container").
-			With(err1).
+			With(err).
 			SetContext("id", agentproxy.String())
 	}
 
-	err = s.containerorchestrator.StartAgentContainer(container, s.AddTearDownCall)
+	return container, nil
+}
+
+// createWarmAgentContainers creates the containers of every registered agent
+// in parallel; startAgentContainer picks them up instead of creating new ones
+func (s *Server) createWarmAgentContainers() error {
+	var wg sync.WaitGroup
+	errs := make(chan error, len(s.agentproxies))
+
+	for _, agentproxy := range s.agentproxies {
+		wg.Add(1)
+
+		go func(agentproxy arenaserveragent.AgentProxyInterface) {
+			defer wg.Done()
+
+			container, err := s.createAgentContainer(agentproxy)
+
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			s.warmcontainersmutex.Lock()
+			s.warmcontainers[agentproxy.GetProxyUUID()] = container
+			s.warmcontainersmutex.Unlock()
+
+		}(agentproxy)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	// Containers that were never started are not auto-removed by docker
+	s.AddTearDownCall(func() error {
+		s.warmcontainersmutex.Lock()
+		defer s.warmcontainersmutex.Unlock()
+
+		for id, container := range s.warmcontainers {
+			err := s.containerorchestrator.DiscardAgentContainer(container)
+
+			if err != nil {
+				s.Log(EventWarn{err})
+			}
+
+			delete(s.warmcontainers, id)
+		}
+
+		return nil
+	})
+
+	for err := range errs {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Server) takeWarmAgentContainer(agentproxy arenaserveragent.AgentProxyInterface) *types.AgentContainer {
+	s.warmcontainersmutex.Lock()
+	defer s.warmcontainersmutex.Unlock()
+
+	container, ok := s.warmcontainers[agentproxy.GetProxyUUID()]
+
+	if !ok {
+		return nil
+	}
+
+	delete(s.warmcontainers, agentproxy.GetProxyUUID())
+
+	return container
+}
+
+func (s *Server) startAgentContainer(
+	agentproxy arenaserveragent.AgentProxyInterface,
+) error {
+	container := s.takeWarmAgentContainer(agentproxy)
+
+	if container == nil {
+		var err error
+		container, err = s.createAgentContainer(agentproxy)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err := s.containerorchestrator.StartAgentContainer(container, s.AddTearDownCall)
 
 	if err != nil {
 		return bettererrors.
@@ -168,7 +409,10 @@ func (s *Server) startAgentContainer(
 		select {
 		case msg := <-wait:
 
-			if !s.gameOver {
+			stopped := s.wasAgentContainerStopped(container)
+			crashed := !s.gameOver && !stopped
+
+			if crashed {
 				berror := bettererrors.
 					New("Agent terminated").
 					SetContext("code", strconv.FormatInt(msg.StatusCode, 10))
@@ -187,26 +431,14 @@ func (s *Server) startAgentContainer(
 			s.agentproxiesmutex.Lock()
 			s.removeAgent(agentproxy.GetProxyUUID())
 			s.agentproxiesmutex.Unlock()
-		case <-err:
-			panic(err)
-		}
-	}()
-
-	go func() {
 
-		for {
-			msg := <-s.containerorchestrator.Events()
-
-			switch t := msg.(type) {
-			case containertypes.EventDebug:
-				s.Log(EventLog{t.Value})
-			case containertypes.EventAgentLog:
-				line := fmt.Sprintf("[%s] %s", t.AgentName, t.Value)
-				s.Log(EventAgentLog{line})
-			default:
-				msg := fmt.Sprintf("Unsupported Orchestrator message of type %s", reflect.TypeOf(msg))
-				panic(msg)
+			if crashed && atomic.LoadInt32(&s.gameIsRunning) == 1 {
+				if agent := s.getAgentByProxyUUID(agentproxy.GetProxyUUID()); agent != nil {
+					s.onAgentContainerExited(agent, msg.StatusCode)
+				}
 			}
+		case <-err:
+			panic(err)
 		}
 	}()
 
@@ -216,6 +448,50 @@ func (s *Server) startAgentContainer(
 	return nil
 }
 
+func (s *Server) consumeOrchestratorEvents() {
+	for {
+		msg := <-s.containerorchestrator.Events()
+
+		switch t := msg.(type) {
+		case containertypes.EventDebug:
+			s.Log(EventLog{t.Value})
+		case containertypes.EventAgentLog:
+			line := fmt.Sprintf("[%s] %s", t.AgentName, t.Value)
+			entry := recording.AgentLogEntry{
+				AgentId:   t.AgentId,
+				AgentName: t.AgentName,
+				Stream:    t.Stream,
+				Tick:      int(atomic.LoadUint32(&s.currentturn)),
+				Time:      time.Now(),
+				Line:      t.Value,
+			}
+
+			s.Log(EventAgentLog{
+				Value: line,
+				Entry: entry,
+			})
+
+			err := s.recorder.RecordAgentLog(s.GetGameDescription().GetId(), entry)
+
+			if err != nil {
+				s.Log(EventWarn{bettererrors.
+					New("Could not record agent log").
+					SetContext("agent", t.AgentName).
+					With(bettererrors.NewFromErr(err))})
+			}
+		case containertypes.EventImagePull:
+			line := fmt.Sprintf("[%s] %s %s", t.Image, t.Status, t.Progress)
+			s.Log(EventImagePull{strings.TrimSpace(line)})
+		case containertypes.EventImageBuild:
+			line := fmt.Sprintf("[%s] %s", t.Image, t.Value)
+			s.Log(EventImageBuild{line})
+		default:
+			msg := fmt.Sprintf("Unsupported Orchestrator message of type %s", reflect.TypeOf(msg))
+			panic(msg)
+		}
+	}
+}
+
 func (s *Server) startAgentContainers() error {
 
 	for _, agentproxy := range s.agentproxies {
@@ -83,6 +83,7 @@ func (server *Server) removeAgent(key uuid.UUID) {
 	delete(server.agentproxies, key)
 	delete(server.agentimages, key)
 	delete(server.agentproxieshandshakes, key)
+	server.stopPerceptionSender(key)
 
 	server.Log(EventDebug{fmt.Sprintf("Removing %s from state", key.String())})
 }
@@ -191,6 +192,19 @@ func (server *Server) DispatchAgentMessage(msg types.AgentMessage) error {
 					SetContext("protocol version", handshake.Version)
 			}
 
+			// Agents with a v2 manifest must use a protocol they declared
+			if gameAgent := server.getAgentByProxyUUID(ag.GetProxyUUID()); gameAgent != nil {
+				manifest := gameAgent.Manifest
+
+				if manifest.ManifestVersion >= types.AGENT_MANIFEST_VERSION_2 && !utils.IsStringInArray(manifest.Protocols, handshake.Version) {
+					return bettererrors.
+						New("Agent protocol is not declared in its manifest").
+						SetContext("agent", ag.String()).
+						SetContext("protocol version", handshake.Version).
+						SetContext("declared protocols", strings.Join(manifest.Protocols, ", "))
+				}
+			}
+
 			ag = ag.SetConn(msg.GetEmitterConn())
 			server.setAgentProxy(ag)
 
@@ -0,0 +1,77 @@
+package container
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/bytearena/core/common/types"
+)
+
+const (
+	AGENT_LOG_STREAM_STDOUT = "stdout"
+	AGENT_LOG_STREAM_STDERR = "stderr"
+
+	AGENT_LOG_MAX_LINE_LENGTH = 4096
+)
+
+// agentLogWriter receives one of the demultiplexed streams of a container
+// and emits an EventAgentLog for every complete line
+type agentLogWriter struct {
+	events    chan interface{}
+	container *types.AgentContainer
+	stream    string
+
+	buf   bytes.Buffer
+	mutex sync.Mutex
+}
+
+func newAgentLogWriter(events chan interface{}, container *types.AgentContainer, stream string) *agentLogWriter {
+	return &agentLogWriter{
+		events:    events,
+		container: container,
+		stream:    stream,
+	}
+}
+
+func (w *agentLogWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.buf.Write(p)
+
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+
+		if i == -1 {
+			break
+		}
+
+		line := w.buf.Next(i + 1)
+		w.emit(line[:i])
+	}
+
+	// Do not let an agent grow the buffer without bounds by never printing a newline
+	if w.buf.Len() > AGENT_LOG_MAX_LINE_LENGTH {
+		w.emit(w.buf.Next(w.buf.Len()))
+	}
+
+	return len(p), nil
+}
+
+func (w *agentLogWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.buf.Len() > 0 {
+		w.emit(w.buf.Next(w.buf.Len()))
+	}
+}
+
+func (w *agentLogWriter) emit(line []byte) {
+	w.events <- EventAgentLog{
+		Value:     string(bytes.TrimRight(line, "\r")),
+		AgentName: w.container.ImageName,
+		AgentId:   w.container.AgentId.String(),
+		Stream:    w.stream,
+	}
+}
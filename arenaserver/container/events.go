@@ -5,4 +5,17 @@ type EventDebug struct{ Value string }
 type EventAgentLog struct {
 	Value     string
 	AgentName string
+	AgentId   string
+	Stream    string
+}
+
+type EventImagePull struct {
+	Image    string
+	Status   string
+	Progress string
+}
+
+type EventImageBuild struct {
+	Image string
+	Value string
 }
@@ -0,0 +1,83 @@
+package container
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	dockertypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/jsonmessage"
+	bettererrors "github.com/xtuc/better-errors"
+
+	"github.com/bytearena/core/common/types"
+)
+
+// CommonBuildAgentImage builds the Dockerfile found in dir and tags the result
+// as dockerimage. Build output is reported on the orchestrator events channel
+// as EventImageBuild.
+func CommonBuildAgentImage(orch types.ContainerOrchestrator, dir string, dockerimage string, labels map[string]string) error {
+	buildContext, err := archive.TarWithOptions(dir, &archive.TarOptions{})
+
+	if err != nil {
+		return bettererrors.
+			New("Failed to create build context").
+			With(bettererrors.NewFromErr(err)).
+			SetContext("dir", dir)
+	}
+
+	defer buildContext.Close()
+
+	response, err := orch.GetCli().ImageBuild(
+		orch.GetContext(),
+		buildContext,
+		dockertypes.ImageBuildOptions{
+			Tags:        []string{dockerimage},
+			Remove:      true,
+			ForceRemove: true,
+			Labels:      labels,
+		},
+	)
+
+	if err != nil {
+		return bettererrors.
+			New("Failed to build image").
+			With(bettererrors.NewFromErr(err)).
+			SetContext("image", dockerimage)
+	}
+
+	defer response.Body.Close()
+
+	decoder := json.NewDecoder(response.Body)
+
+	for {
+		var msg jsonmessage.JSONMessage
+
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return bettererrors.
+				New("Failed to read build output").
+				With(bettererrors.NewFromErr(err)).
+				SetContext("image", dockerimage)
+		}
+
+		if msg.Error != nil {
+			return bettererrors.
+				New("Failed to build image").
+				With(bettererrors.NewFromErr(msg.Error)).
+				SetContext("image", dockerimage)
+		}
+
+		if line := strings.TrimSpace(msg.Stream); line != "" {
+			orch.Events() <- EventImageBuild{
+				Image: dockerimage,
+				Value: line,
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package container
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	dockertypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	bettererrors "github.com/xtuc/better-errors"
+
+	"github.com/bytearena/core/common/types"
+)
+
+const (
+	PULL_PROGRESS_STEP = 25 // percent
+)
+
+// CommonPullImages pulls every image that is not available locally yet.
+// Pulls happen in parallel; their progress is reported on the orchestrator
+// events channel as EventImagePull.
+func CommonPullImages(orch types.ContainerOrchestrator, dockerimages []string) error {
+	normalizedDockerimages := make(map[string]string)
+
+	for _, dockerimage := range dockerimages {
+		normalizedDockerimage, err := normalizeDockerRef(dockerimage)
+
+		if err != nil {
+			return bettererrors.
+				New("Invalid docker image").
+				SetContext("image", dockerimage).
+				With(bettererrors.NewFromErr(err))
+		}
+
+		normalizedDockerimages[normalizedDockerimage] = dockerimage
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(normalizedDockerimages))
+
+	for normalizedDockerimage, dockerimage := range normalizedDockerimages {
+		wg.Add(1)
+
+		go func(normalizedDockerimage, dockerimage string) {
+			defer wg.Done()
+
+			foundlocal, err := commonIsImageLocal(orch, normalizedDockerimage)
+
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			if foundlocal {
+				return
+			}
+
+			if err := commonPullImage(orch, dockerimage); err != nil {
+				errs <- err
+			}
+		}(normalizedDockerimage, dockerimage)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	// Report the first failure; the others are most likely the same registry issue
+	for err := range errs {
+		return err
+	}
+
+	return nil
+}
+
+func commonIsImageLocal(orch types.ContainerOrchestrator, normalizedDockerimage string) (bool, error) {
+	_, _, err := orch.GetCli().ImageInspectWithRaw(orch.GetContext(), normalizedDockerimage)
+
+	if err == nil {
+		return true, nil
+	}
+
+	if client.IsErrImageNotFound(err) {
+		return false, nil
+	}
+
+	return false, bettererrors.
+		New("Failed to inspect local image").
+		SetContext("image", normalizedDockerimage).
+		With(bettererrors.NewFromErr(err))
+}
+
+func commonPullImage(orch types.ContainerOrchestrator, dockerimage string) error {
+	reader, err := orch.GetCli().ImagePull(
+		orch.GetContext(),
+		dockerimage,
+		dockertypes.ImagePullOptions{
+			RegistryAuth: orch.GetRegistryAuth(),
+		},
+	)
+
+	if err != nil {
+		return bettererrors.
+			New("Failed to pull from registry").
+			With(bettererrors.NewFromErr(err)).
+			SetContext("image", dockerimage)
+	}
+
+	defer reader.Close()
+
+	decoder := json.NewDecoder(reader)
+	lastReportedPercent := make(map[string]int64)
+
+	for {
+		var msg jsonmessage.JSONMessage
+
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return bettererrors.
+				New("Failed to read pull progress").
+				With(bettererrors.NewFromErr(err)).
+				SetContext("image", dockerimage)
+		}
+
+		if msg.Error != nil {
+			return bettererrors.
+				New("Failed to pull from registry").
+				With(bettererrors.NewFromErr(msg.Error)).
+				SetContext("image", dockerimage)
+		}
+
+		// Download progress is emitted many times per second for each layer;
+		// only forward it every PULL_PROGRESS_STEP percent
+		if msg.Progress != nil && msg.Progress.Total > 0 {
+			percent := msg.Progress.Current * 100 / msg.Progress.Total
+			last, reported := lastReportedPercent[msg.ID]
+
+			if reported && percent-last < PULL_PROGRESS_STEP {
+				continue
+			}
+
+			lastReportedPercent[msg.ID] = percent
+		}
+
+		status := msg.Status
+		if msg.ID != "" {
+			status = msg.ID + ": " + status
+		}
+
+		progress := ""
+		if msg.Progress != nil {
+			progress = msg.Progress.String()
+		}
+
+		orch.Events() <- EventImagePull{
+			Image:    dockerimage,
+			Status:   status,
+			Progress: progress,
+		}
+	}
+
+	return nil
+}
@@ -1,16 +1,15 @@
 package container
 
 import (
-	"bufio"
 	"context"
 	"errors"
-	"fmt"
-	"os"
+	"sync"
 	"time"
 
 	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/bytearena/core/common/types"
@@ -24,6 +23,8 @@ type LocalContainerOrchestrator struct {
 	host         string
 	containers   []*types.AgentContainer
 	events       chan interface{}
+
+	containersmutex *sync.Mutex
 }
 
 const (
@@ -42,12 +43,14 @@ func (orch *LocalContainerOrchestrator) startContainerLocalOrch(ctner *types.Age
 		return err
 	}
 
-	err = orch.localLogsToStdOut(ctner)
+	err = orch.SetAgentLogger(ctner)
 
 	if err != nil {
 		return errors.New("Failed to follow docker container logs for " + ctner.Containerid)
 	}
 
+	go orch.followAgentLogs(ctner)
+
 	containerInfo, err := orch.cli.ContainerInspect(
 		orch.ctx,
 		ctner.Containerid,
@@ -74,6 +77,8 @@ func MakeLocalContainerOrchestrator(host string) types.ContainerOrchestrator {
 		host:         host,
 		registryAuth: registryAuth,
 		events:       make(chan interface{}, LOG_ENTRY_BUFFER),
+
+		containersmutex: &sync.Mutex{},
 	}
 }
 
@@ -90,47 +95,22 @@ func (orch *LocalContainerOrchestrator) GetHost() (string, error) {
 	return orch.host, nil
 }
 
-func (orch *LocalContainerOrchestrator) localLogsToStdOut(container *types.AgentContainer) error {
-
-	go func(orch *LocalContainerOrchestrator, container *types.AgentContainer) {
+func (orch *LocalContainerOrchestrator) followAgentLogs(container *types.AgentContainer) {
+	stdout := newAgentLogWriter(orch.events, container, AGENT_LOG_STREAM_STDOUT)
+	stderr := newAgentLogWriter(orch.events, container, AGENT_LOG_STREAM_STDERR)
 
-		reader, err := orch.cli.ContainerLogs(orch.ctx, container.Containerid, dockertypes.ContainerLogsOptions{
-			ShowStdout: true,
-			ShowStderr: true,
-			Follow:     true,
-			Details:    false,
-			Timestamps: false,
-		})
+	defer container.LogReader.Close()
 
-		utils.Check(err, "Could not read container logs for "+container.AgentId.String()+"; container="+container.Containerid)
+	// Containers are created without a TTY; docker multiplexes stdout and
+	// stderr in a single stream with a header in front of each frame
+	_, err := stdcopy.StdCopy(stdout, stderr, container.LogReader)
 
-		defer reader.Close()
-
-		scanner := bufio.NewScanner(reader)
-		for scanner.Scan() {
-			buf := scanner.Bytes()
-
-			/*
-				This is to remove Docker log header.
-				First 8 bytes are part of the header.
-			*/
-			if len(buf) > 8 {
-				buf = buf[8:]
-			}
-
-			orch.events <- EventAgentLog{
-				Value:     string(buf),
-				AgentName: container.ImageName,
-			}
-		}
+	stdout.Flush()
+	stderr.Flush()
 
-		if err := scanner.Err(); err != nil {
-			fmt.Fprintln(os.Stderr, "reading standard input:", err)
-		}
-
-	}(orch, container)
-
-	return nil
+	if err != nil {
+		orch.events <- EventDebug{"Stopped following logs of " + container.ImageName + ": " + err.Error()}
+	}
 }
 
 func (orch *LocalContainerOrchestrator) StartAgentContainer(ctner *types.AgentContainer, addTearDownCall func(types.TearDownCallback)) error {
@@ -143,6 +123,18 @@ func (orch *LocalContainerOrchestrator) CreateAgentContainer(agentid uuid.UUID,
 	return CommonCreateAgentContainer(orch, agentid, host, port, dockerimage)
 }
 
+func (orch *LocalContainerOrchestrator) PullImages(dockerimages []string) error {
+	return CommonPullImages(orch, dockerimages)
+}
+
+func (orch *LocalContainerOrchestrator) BuildAgentImage(dir string, dockerimage string, labels map[string]string) error {
+	return CommonBuildAgentImage(orch, dir, dockerimage, labels)
+}
+
+func (orch *LocalContainerOrchestrator) DiscardAgentContainer(ctner *types.AgentContainer) error {
+	return CommonDiscardAgentContainer(orch, ctner)
+}
+
 func (orch *LocalContainerOrchestrator) TearDown(container *types.AgentContainer) {
 	timeout := 5 * time.Second
 
@@ -175,12 +167,29 @@ func (orch *LocalContainerOrchestrator) Wait(ctner *types.AgentContainer) (<-cha
 }
 
 func (orch *LocalContainerOrchestrator) SetAgentLogger(container *types.AgentContainer) error {
-	// TODO(sven): implement log to stdout here
+	reader, err := orch.cli.ContainerLogs(orch.ctx, container.Containerid, dockertypes.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Details:    false,
+		Timestamps: false,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	container.SetLogger(reader, nil)
+
 	return nil
 }
 
 func (orch *LocalContainerOrchestrator) TearDownAll() error {
-	for _, container := range orch.containers {
+	orch.containersmutex.Lock()
+	containers := orch.containers
+	orch.containersmutex.Unlock()
+
+	for _, container := range containers {
 		orch.TearDown(container)
 	}
 
@@ -200,10 +209,16 @@ func (orch *LocalContainerOrchestrator) GetRegistryAuth() string {
 }
 
 func (orch *LocalContainerOrchestrator) AddContainer(ctner *types.AgentContainer) {
+	orch.containersmutex.Lock()
+	defer orch.containersmutex.Unlock()
+
 	orch.containers = append(orch.containers, ctner)
 }
 
 func (orch *LocalContainerOrchestrator) RemoveContainer(ctner *types.AgentContainer) {
+	orch.containersmutex.Lock()
+	defer orch.containersmutex.Unlock()
+
 	containers := make([]*types.AgentContainer, 0)
 
 	for _, c := range orch.containers {
@@ -2,15 +2,12 @@ package container
 
 import (
 	"errors"
-	"os"
 	"strconv"
 
 	"github.com/docker/distribution/reference"
-	"github.com/docker/docker/pkg/term"
 
 	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/pkg/jsonmessage"
 	uuid "github.com/satori/go.uuid"
 	bettererrors "github.com/xtuc/better-errors"
 
@@ -39,46 +36,12 @@ func CommonCreateAgentContainer(orch types.ContainerOrchestrator, agentid uuid.U
 		return nil, bettererrors.NewFromErr(err)
 	}
 
-	localimages, _ := orch.GetCli().ImageList(orch.GetContext(), dockertypes.ImageListOptions{})
-	foundlocal := false
-	for _, localimage := range localimages {
-		for _, alias := range localimage.RepoTags {
-			if normalizedAlias, err := normalizeDockerRef(alias); err == nil {
-				if normalizedAlias == normalizedDockerimage {
-					foundlocal = true
-					break
-				}
-			}
-		}
-
-		if foundlocal {
-			break
-		}
-	}
+	// Images are normally pulled ahead by CommonPullImages; this only pulls
+	// when an agent is (re)started outside of the game description
+	err = CommonPullImages(orch, []string{dockerimage})
 
-	if !foundlocal {
-		reader, err := orch.GetCli().ImagePull(
-			orch.GetContext(),
-			dockerimage,
-			dockertypes.ImagePullOptions{
-				RegistryAuth: orch.GetRegistryAuth(),
-			},
-		)
-
-		if err != nil {
-			return nil, bettererrors.
-				New("Failed to pull from registry").
-				With(bettererrors.NewFromErr(err)).
-				SetContext("image", dockerimage)
-		}
-
-		fd, isTerminal := term.GetFdInfo(os.Stdout)
-
-		if err := jsonmessage.DisplayJSONMessagesStream(reader, os.Stdout, fd, isTerminal, nil); err != nil {
-			return nil, err
-		}
-
-		reader.Close()
+	if err != nil {
+		return nil, err
 	}
 
 	containerconfig := container.Config{
@@ -123,3 +86,22 @@ func CommonCreateAgentContainer(orch types.ContainerOrchestrator, agentid uuid.U
 
 	return agentcontainer, nil
 }
+
+func CommonDiscardAgentContainer(orch types.ContainerOrchestrator, ctner *types.AgentContainer) error {
+	err := orch.GetCli().ContainerRemove(
+		orch.GetContext(),
+		ctner.Containerid,
+		dockertypes.ContainerRemoveOptions{Force: true},
+	)
+
+	orch.RemoveContainer(ctner)
+
+	if err != nil {
+		return bettererrors.
+			New("Failed to remove docker container").
+			With(bettererrors.NewFromErr(err)).
+			SetContext("container", ctner.Containerid)
+	}
+
+	return nil
+}
@@ -1,5 +1,7 @@
 package arenaserver
 
+import "github.com/bytearena/core/common/recording"
+
 type EventStatusGameUpdate struct{ Status string }
 type EventClose struct{}
 type EventLog struct{ Value string }
@@ -7,8 +9,13 @@ type EventHeadsUp struct{ Value string }
 type EventError struct{ Err error }
 type EventDebug struct{ Value string }
 type EventWarn struct{ Err error }
-type EventAgentLog struct{ Value string }
+type EventAgentLog struct {
+	Value string
+	Entry recording.AgentLogEntry
+}
 type EventOrchestratorLog struct{ Value string }
+type EventImagePull struct{ Value string }
+type EventImageBuild struct{ Value string }
 type EventRawComm struct {
 	Value []byte
 	From  string
@@ -0,0 +1,190 @@
+package arenaserver
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	bettererrors "github.com/xtuc/better-errors"
+
+	"github.com/bytearena/core/common/types"
+)
+
+const (
+	HOT_RELOAD_POLL_INTERVAL = 1 * time.Second
+)
+
+type sourceDirFingerprint struct {
+	nbFiles     int
+	totalSize   int64
+	lastModTime time.Time
+}
+
+func getSourceDirFingerprint(dir string) (sourceDirFingerprint, error) {
+	var fingerprint sourceDirFingerprint
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		fingerprint.nbFiles++
+		fingerprint.totalSize += info.Size()
+
+		if info.ModTime().After(fingerprint.lastModTime) {
+			fingerprint.lastModTime = info.ModTime()
+		}
+
+		return nil
+	})
+
+	return fingerprint, err
+}
+
+// WatchAgentSource rebuilds the image of the agent and reloads it every time
+// its source directory changes. The directory must contain the agent manifest
+// (ba.json) and a Dockerfile. Build errors are reported as events and the
+// agent keeps running its previous image.
+func (s *Server) WatchAgentSource(agent *types.Agent, dir string) error {
+	_, err := s.parseWatchedAgentManifest(agent, dir)
+
+	if err != nil {
+		return err
+	}
+
+	fingerprint, err := getSourceDirFingerprint(dir)
+
+	if err != nil {
+		return bettererrors.
+			New("Could not read agent source directory").
+			SetContext("dir", dir).
+			With(bettererrors.NewFromErr(err))
+	}
+
+	stop := make(chan bool)
+
+	s.AddTearDownCall(func() error {
+		close(stop)
+		return nil
+	})
+
+	go func() {
+		ticker := time.NewTicker(HOT_RELOAD_POLL_INTERVAL)
+		defer ticker.Stop()
+
+		pending := false
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
+			current, err := getSourceDirFingerprint(dir)
+
+			if err != nil {
+				s.Log(EventWarn{bettererrors.
+					New("Could not read agent source directory").
+					SetContext("dir", dir).
+					With(bettererrors.NewFromErr(err))})
+
+				continue
+			}
+
+			if current != fingerprint {
+				// Wait until the files stop changing before building
+				fingerprint = current
+				pending = true
+				continue
+			}
+
+			if !pending {
+				continue
+			}
+
+			pending = false
+
+			err = s.hotReloadAgent(agent, dir)
+
+			if err != nil {
+				s.Log(EventError{err})
+			}
+		}
+	}()
+
+	s.Log(EventHeadsUp{"Watching " + dir + " for changes to agent " + agent.Manifest.Id})
+
+	return nil
+}
+
+func (s *Server) parseWatchedAgentManifest(agent *types.Agent, dir string) (types.AgentManifest, error) {
+	manifest, err := types.ParseAgentManifestFromDir(dir)
+
+	if err != nil {
+		return manifest, err
+	}
+
+	err = types.ValidateAgentManifest(manifest)
+
+	if err != nil {
+		return manifest, bettererrors.
+			New("Invalid agent manifest").
+			SetContext("dir", dir).
+			With(err)
+	}
+
+	if manifest.Id != agent.Manifest.Id {
+		return manifest, bettererrors.
+			New("Agent manifest id does not match the running agent").
+			SetContext("dir", dir).
+			SetContext("manifest id", manifest.Id).
+			SetContext("agent id", agent.Manifest.Id)
+	}
+
+	return manifest, nil
+}
+
+func (s *Server) hotReloadAgent(agent *types.Agent, dir string) error {
+	manifest, err := s.parseWatchedAgentManifest(agent, dir)
+
+	if err != nil {
+		return err
+	}
+
+	s.Log(EventHeadsUp{"Rebuilding agent " + agent.Manifest.Id})
+
+	err = s.containerorchestrator.BuildAgentImage(dir, agent.Manifest.Id, map[string]string{
+		types.AGENT_MANIFEST_LABEL_KEY: manifest.String(),
+	})
+
+	if err != nil {
+		return bettererrors.
+			New("Failed to rebuild agent; keeping the previous version").
+			SetContext("agent", agent.Manifest.Id).
+			With(err)
+	}
+
+	agent.Manifest = manifest
+
+	err = s.ReloadAgent(agent)
+
+	if err != nil {
+		return bettererrors.
+			New("Failed to reload agent").
+			SetContext("agent", agent.Manifest.Id).
+			With(err)
+	}
+
+	s.Log(EventHeadsUp{"Agent " + agent.Manifest.Id + " reloaded"})
+
+	return nil
+}
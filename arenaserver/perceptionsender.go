@@ -0,0 +1,95 @@
+package arenaserver
+
+import (
+	"sync"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Sends the perceptions of one agent, one at a time
+// Only the latest perception is kept: a perception offered while the previous one is still
+// waiting replaces it, so that an agent not reading its socket never stalls the game loop
+type perceptionSender struct {
+	latest chan func() // holds at most one pending send
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newPerceptionSender() *perceptionSender {
+	sender := &perceptionSender{
+		latest: make(chan func(), 1),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		for {
+			select {
+			case <-sender.done:
+				return
+			case send := <-sender.latest:
+				send()
+			}
+		}
+	}()
+
+	return sender
+}
+
+// Never blocks; returns false if a stale perception was dropped
+func (sender *perceptionSender) Offer(send func()) bool {
+	fresh := true
+
+	for {
+		select {
+		case sender.latest <- send:
+			return fresh
+		default:
+		}
+
+		select {
+		case <-sender.latest:
+			fresh = false
+		default:
+		}
+	}
+}
+
+// The pending perception, if any, is dropped
+func (sender *perceptionSender) Stop() {
+	sender.once.Do(func() {
+		close(sender.done)
+	})
+}
+
+func (server *Server) getPerceptionSender(proxyUUID uuid.UUID) *perceptionSender {
+	server.perceptionsendersmutex.Lock()
+	defer server.perceptionsendersmutex.Unlock()
+
+	sender, ok := server.perceptionsenders[proxyUUID]
+	if !ok {
+		sender = newPerceptionSender()
+		server.perceptionsenders[proxyUUID] = sender
+	}
+
+	return sender
+}
+
+func (server *Server) stopPerceptionSender(proxyUUID uuid.UUID) {
+	server.perceptionsendersmutex.Lock()
+	defer server.perceptionsendersmutex.Unlock()
+
+	if sender, ok := server.perceptionsenders[proxyUUID]; ok {
+		sender.Stop()
+		delete(server.perceptionsenders, proxyUUID)
+	}
+}
+
+func (server *Server) stopPerceptionSenders() {
+	server.perceptionsendersmutex.Lock()
+	defer server.perceptionsendersmutex.Unlock()
+
+	for proxyUUID, sender := range server.perceptionsenders {
+		sender.Stop()
+		delete(server.perceptionsenders, proxyUUID)
+	}
+}
@@ -0,0 +1,87 @@
+package arenaserver
+
+import (
+	"strconv"
+	"strings"
+
+	bettererrors "github.com/xtuc/better-errors"
+)
+
+type RestartPolicyMode string
+
+const (
+	RestartPolicyNever     RestartPolicyMode = "never"
+	RestartPolicyOnFailure RestartPolicyMode = "on-failure"
+	RestartPolicyAlways    RestartPolicyMode = "always"
+)
+
+// RestartPolicy tells what to do when an agent container exits during a match.
+// MaxRetries only applies to on-failure; 0 means unlimited.
+type RestartPolicy struct {
+	Mode       RestartPolicyMode
+	MaxRetries int
+}
+
+func MakeRestartPolicyNever() RestartPolicy {
+	return RestartPolicy{Mode: RestartPolicyNever}
+}
+
+// ParseRestartPolicy reads a policy written like docker's --restart flag:
+// "never", "always", "on-failure" or "on-failure:3"
+func ParseRestartPolicy(policy string) (RestartPolicy, error) {
+	parts := strings.SplitN(policy, ":", 2)
+
+	switch RestartPolicyMode(parts[0]) {
+	case RestartPolicyNever, RestartPolicyAlways:
+		if len(parts) > 1 {
+			return RestartPolicy{}, bettererrors.
+				New("Max retries are only supported with on-failure").
+				SetContext("policy", policy)
+		}
+
+		return RestartPolicy{Mode: RestartPolicyMode(parts[0])}, nil
+
+	case RestartPolicyOnFailure:
+		maxRetries := 0
+
+		if len(parts) > 1 {
+			var err error
+			maxRetries, err = strconv.Atoi(parts[1])
+
+			if err != nil || maxRetries < 0 {
+				return RestartPolicy{}, bettererrors.
+					New("Invalid max retries").
+					SetContext("policy", policy)
+			}
+		}
+
+		return RestartPolicy{Mode: RestartPolicyOnFailure, MaxRetries: maxRetries}, nil
+	}
+
+	return RestartPolicy{}, bettererrors.
+		New("Unknown restart policy").
+		SetContext("policy", policy)
+}
+
+func (p RestartPolicy) ShouldRestart(exitCode int64, restarts int) bool {
+	switch p.Mode {
+	case RestartPolicyAlways:
+		return true
+	case RestartPolicyOnFailure:
+		if exitCode == 0 {
+			return false
+		}
+
+		return p.MaxRetries == 0 || restarts < p.MaxRetries
+	}
+
+	return false
+}
+
+func (p RestartPolicy) String() string {
+	if p.Mode == RestartPolicyOnFailure && p.MaxRetries > 0 {
+		return string(p.Mode) + ":" + strconv.Itoa(p.MaxRetries)
+	}
+
+	return string(p.Mode)
+}
@@ -14,13 +14,14 @@ import (
 	"github.com/phayes/freeport"
 	uuid "github.com/satori/go.uuid"
 
+	"github.com/bytearena/ecs"
 	bettererrors "github.com/xtuc/better-errors"
 
 	"github.com/bytearena/core/common/mq"
+	"github.com/bytearena/core/common/recording"
 	"github.com/bytearena/core/common/types"
-	"github.com/bytearena/core/common/types/mapcontainer"
 	"github.com/bytearena/core/common/utils"
-	"github.com/bytearena/core/common/utils/vector"
+	"github.com/bytearena/core/common/utils/space"
 	commongame "github.com/bytearena/core/game/common"
 )
 
@@ -54,13 +55,28 @@ type Server struct {
 	agentproxieshandshakes map[uuid.UUID]struct{}
 	agentimages            map[uuid.UUID]string
 	agentcontainers        map[uuid.UUID]*types.AgentContainer
-	agentspawnedvector     map[uuid.UUID]*vector.Vector2
+	agentspawnedvector     map[uuid.UUID]*space.MapVector2
+
+	agentimagespulled   bool
+	agentimagesmutex    *sync.Mutex // held for the whole pull; warm-up and Start may both pull
+	warmcontainers      map[uuid.UUID]*types.AgentContainer
+	warmcontainersmutex *sync.Mutex
+
+	restartpolicy      RestartPolicy
+	agentrestarts      map[ecs.EntityID]int
+	stoppedcontainers  map[string]struct{}
+	agentrestartsmutex *sync.Mutex
 
 	pendingmutations []types.AgentMutationBatch
 	mutationsmutex   *sync.Mutex
 
 	tickdurations []int64
 
+	recorder recording.RecorderInterface
+
+	perceptionsenders      map[uuid.UUID]*perceptionSender // one per agent proxy
+	perceptionsendersmutex *sync.Mutex
+
 	///////////////////////////////////////////////////////////////////////
 	// Game logic
 	///////////////////////////////////////////////////////////////////////
@@ -129,13 +145,28 @@ func NewServer(
 		agentproxieshandshakes: make(map[uuid.UUID]struct{}),
 		agentimages:            make(map[uuid.UUID]string),
 		agentcontainers:        make(map[uuid.UUID]*types.AgentContainer),
-		agentspawnedvector:     make(map[uuid.UUID]*vector.Vector2),
+		agentspawnedvector:     make(map[uuid.UUID]*space.MapVector2),
+
+		agentimagespulled:   false,
+		agentimagesmutex:    &sync.Mutex{},
+		warmcontainers:      make(map[uuid.UUID]*types.AgentContainer),
+		warmcontainersmutex: &sync.Mutex{},
+
+		restartpolicy:      MakeRestartPolicyNever(),
+		agentrestarts:      make(map[ecs.EntityID]int),
+		stoppedcontainers:  make(map[string]struct{}),
+		agentrestartsmutex: &sync.Mutex{},
 
 		pendingmutations: make([]types.AgentMutationBatch, 0),
 		mutationsmutex:   &sync.Mutex{},
 
 		tickdurations: make([]int64, 0),
 
+		recorder: recording.MakeEmptyRecorder(),
+
+		perceptionsenders:      make(map[uuid.UUID]*perceptionSender),
+		perceptionsendersmutex: &sync.Mutex{},
+
 		///////////////////////////////////////////////////////////////////////
 		// Game logic
 		///////////////////////////////////////////////////////////////////////
@@ -150,11 +181,34 @@ func NewServer(
 		isDebug: isDebug,
 	}
 
-	game.Initialize(func() {
+	game.Initialize(func(reason string) {
 		// cbkGameOver
-		s.Stop()
+		s.gameOver = true
+		s.Log(EventHeadsUp{"Game over (" + reason + ")"})
+		notify.Post("app:stopticking", true) // gameover: true
+	})
+
+	for _, issue := range game.GetMapGeometryIssues() {
+		if issue.Skipped {
+			s.Log(EventWarn{bettererrors.
+				New("Invalid map geometry; polygon not loaded").
+				SetContext("polygon", issue.String())})
+		}
+	}
+
+	if gameDuration != nil {
+		// enforced by the match rules of the game
+		game.SetWallClockLimit(*gameDuration)
+	}
+
+	s.AddTearDownCall(func() error {
+		s.stopPerceptionSenders()
+		s.game.TearDown()
+		return nil
 	})
 
+	go s.consumeOrchestratorEvents()
+
 	return s
 }
 
@@ -166,10 +220,40 @@ func (s Server) getNbExpectedagents() int {
 // Public API
 ///////////////////////////////////////////////////////////////////////////////
 
+// WarmUp pulls the agent images and creates the agent containers ahead of
+// Start(), which then only has to start them. Calling it is optional.
+func (server *Server) WarmUp() error {
+	err := server.pullAgentImages()
+
+	if err != nil {
+		return bettererrors.New("Failed to pull agent images").With(err)
+	}
+
+	err = server.createWarmAgentContainers()
+
+	if err != nil {
+		return bettererrors.New("Failed to create agent containers").With(err)
+	}
+
+	return nil
+}
+
 func (server *Server) Start() (chan interface{}, error) {
 
+	err := server.checkAgentsCompatibility()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.pullAgentImages()
+
+	if err != nil {
+		return nil, bettererrors.New("Failed to pull agent images").With(err)
+	}
+
 	block := server.listen()
-	err := server.startAgentContainers()
+	err = server.startAgentContainers()
 
 	if err != nil {
 		return nil, bettererrors.New("Failed to start agent containers").With(err)
@@ -223,6 +307,18 @@ func (s *Server) SendLaunched() {
 	s.Log(EventLog{"Send game launched: " + string(payloadJson)})
 }
 
+// SetRestartPolicy configures what happens when an agent container exits
+// during the match; agents are not restarted by default
+func (s *Server) SetRestartPolicy(policy RestartPolicy) {
+	s.restartpolicy = policy
+}
+
+// SetRecorder attaches the recorder of the game; the agent logs are recorded
+// with it, per agent. Nothing is recorded by default
+func (s *Server) SetRecorder(recorder recording.RecorderInterface) {
+	s.recorder = recorder
+}
+
 func (s Server) GetGameDescription() types.GameDescriptionInterface {
 	return s.gameDescription
 }
@@ -271,12 +367,6 @@ func (server *Server) startTicking() {
 
 	if server.gameDuration != nil {
 		server.Log(EventHeadsUp{"Game will run for " + server.gameDuration.String()})
-		go func() {
-			<-time.After(*server.gameDuration)
-			server.gameOver = true
-			server.Log(EventHeadsUp{"Game ended after " + server.gameDuration.String()})
-			notify.Post("app:stopticking", true) // gameover: true
-		}()
 	} else {
 		server.Log(EventHeadsUp{"Game will run indefinitely"})
 	}
@@ -324,9 +414,10 @@ func (server *Server) doTick() {
 	// Refreshing perception for every agent
 	///////////////////////////////////////////////////////////////////////////
 
-	arenamap := server.GetGameDescription().GetMapContainer()
+	// Never blocks: an agent that does not keep up only receives the latest perception
 	for _, agentproxy := range server.agentproxies {
-		go func(server *Server, agentproxy agent.AgentProxyInterface, arenamap *mapcontainer.MapContainer) {
+		agentproxy := agentproxy
+		server.getPerceptionSender(agentproxy.GetProxyUUID()).Offer(func() {
 
 			agentPerception := server.
 				GetGame().
@@ -342,8 +433,7 @@ func (server *Server) doTick() {
 
 				server.Log(EventError{berror})
 			}
-
-		}(server, agentproxy, arenamap)
+		})
 	}
 
 	server.gameStepMutex.Unlock()
@@ -28,6 +28,7 @@ var dockerfileInstructionWhitelist = Whitelist{
 	"CMD":        nil,
 	"ENTRYPOINT": nil,
 	"ENV":        nil,
+	"LABEL":      nil,
 }
 
 func DockerfileParserGetFroms(source io.Reader) ([]string, error) {
@@ -37,8 +38,8 @@ func DockerfileParserGetFroms(source io.Reader) ([]string, error) {
 	}
 
 	fromValues := make([]string, 0)
-	visitRow(result.AST, func(node *dockerfileparser.Node) {
-		if node.Value == "from" {
+	visitNodes(result.AST, func(node *dockerfileparser.Node) {
+		if node.Value == "from" && node.Next != nil {
 			fromValues = append(fromValues, node.Next.Value)
 		}
 	})
@@ -54,7 +55,7 @@ func DockerfileFindForbiddenInstructions(source io.Reader) (map[Instruction]inte
 		return nil, err
 	}
 
-	visitRow(result.AST, func(node *dockerfileparser.Node) {
+	visitNodes(result.AST, func(node *dockerfileparser.Node) {
 		instruction := Instruction(strings.ToUpper(node.Value))
 		_, isWhitelisted := dockerfileInstructionWhitelist[instruction]
 
@@ -66,8 +67,14 @@ func DockerfileFindForbiddenInstructions(source io.Reader) (map[Instruction]inte
 	return res, nil
 }
 
-func visitRow(node *dockerfileparser.Node, cbk func(n *dockerfileparser.Node)) {
+// visitNodes calls cbk for every instruction, including the ones nested in
+// ONBUILD triggers
+func visitNodes(node *dockerfileparser.Node, cbk func(n *dockerfileparser.Node)) {
 	for _, n := range node.Children {
 		cbk(n)
+
+		for next := n.Next; next != nil; next = next.Next {
+			visitNodes(next, cbk)
+		}
 	}
 }
@@ -0,0 +1,304 @@
+package dockerfile
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	dockerfileparser "github.com/docker/docker/builder/dockerfile/parser"
+	bettererrors "github.com/xtuc/better-errors"
+
+	"github.com/bytearena/core/common/types"
+)
+
+const (
+	RULE_PARSE          = "parse"
+	RULE_INSTRUCTION    = "instruction"
+	RULE_BASE_IMAGE     = "base-image"
+	RULE_REGISTRY       = "registry"
+	RULE_RUN_PATTERN    = "run-pattern"
+	RULE_REQUIRED_LABEL = "required-label"
+)
+
+type BannedPattern struct {
+	Pattern *regexp.Regexp
+	Hint    string
+}
+
+// Policy describes what an agent Dockerfile is allowed to contain.
+// Empty AllowedBaseImages or AllowedRegistries lists allow anything.
+type Policy struct {
+	AllowedInstructions Whitelist
+	AllowedBaseImages   []string // path.Match patterns on the image name, eg "node", "library/*"
+	AllowedRegistries   []string // eg "docker.io"
+	BannedRunPatterns   []BannedPattern
+	RequiredLabels      []string
+}
+
+type Violation struct {
+	Rule        string
+	Instruction Instruction
+	Line        SourceLoc
+	Message     string
+	Hint        string
+}
+
+func (v Violation) String() string {
+	res := v.Message
+
+	// Line 0 is used for violations that concern the whole file
+	if v.Line > 0 {
+		res = "line " + v.Line.String() + ": " + res
+	}
+
+	if v.Hint != "" {
+		res += " (" + v.Hint + ")"
+	}
+
+	return res
+}
+
+func MakeDefaultPolicy() Policy {
+	return Policy{
+		AllowedInstructions: dockerfileInstructionWhitelist,
+		AllowedBaseImages:   []string{},
+		AllowedRegistries:   []string{"docker.io"},
+		BannedRunPatterns: []BannedPattern{
+			{
+				Pattern: regexp.MustCompile(`(^|[\s;&|])(curl|wget)\s[^|]*\|\s*(ba|z)?sh\b`),
+				Hint:    "download the script with COPY and review it instead of piping it to a shell",
+			},
+			{
+				Pattern: regexp.MustCompile(`(^|[\s;&|])(sudo|su)\s`),
+				Hint:    "the agent container runs without capabilities; remove sudo/su",
+			},
+			{
+				Pattern: regexp.MustCompile(`(^|[\s;&|])(nc|ncat|netcat)\s`),
+				Hint:    "agents may only talk to the arena through the provided socket",
+			},
+		},
+		RequiredLabels: []string{types.AGENT_MANIFEST_LABEL_KEY},
+	}
+}
+
+// Check parses the Dockerfile and returns every policy violation found in it
+func (policy Policy) Check(source io.Reader) ([]Violation, error) {
+	result, err := dockerfileparser.Parse(source)
+
+	if err != nil {
+		return nil, bettererrors.
+			New("Could not parse Dockerfile").
+			With(bettererrors.NewFromErr(err))
+	}
+
+	violations := make([]Violation, 0)
+	stages := make(map[string]interface{})
+	labels := make(map[string]interface{})
+	line := SourceLoc(0)
+
+	visitNodes(result.AST, func(node *dockerfileparser.Node) {
+		instruction := Instruction(strings.ToUpper(node.Value))
+
+		// ONBUILD triggers have no line of their own; they are visited right after their ONBUILD
+		if node.StartLine > 0 {
+			line = SourceLoc(node.StartLine)
+		}
+
+		if policy.AllowedInstructions != nil {
+			if _, isWhitelisted := policy.AllowedInstructions[instruction]; !isWhitelisted {
+				violations = append(violations, Violation{
+					Rule:        RULE_INSTRUCTION,
+					Instruction: instruction,
+					Line:        line,
+					Message:     instruction.String() + " is not allowed",
+					Hint:        "remove it; allowed instructions are " + policy.allowedInstructionsString(),
+				})
+			}
+		}
+
+		switch instruction {
+		case "FROM":
+			violations = append(violations, policy.checkFrom(node, line, stages)...)
+		case "RUN":
+			violations = append(violations, policy.checkRun(node, line)...)
+		case "LABEL":
+			// Keys and values alternate: LABEL key1=value1 key2=value2
+			for next := node.Next; next != nil; next = next.Next {
+				labels[unquote(next.Value)] = nil
+
+				if next.Next == nil {
+					break
+				}
+
+				next = next.Next
+			}
+		}
+	})
+
+	for _, label := range policy.RequiredLabels {
+		if _, found := labels[label]; !found {
+			violations = append(violations, Violation{
+				Rule:        RULE_REQUIRED_LABEL,
+				Instruction: "LABEL",
+				Line:        SourceLoc(0),
+				Message:     "missing LABEL " + label,
+				Hint:        fmt.Sprintf("add LABEL %s=\"...\"", label),
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+func (policy Policy) checkFrom(node *dockerfileparser.Node, line SourceLoc, stages map[string]interface{}) []Violation {
+	violations := make([]Violation, 0)
+
+	if node.Next == nil {
+		return violations
+	}
+
+	image := node.Next.Value
+	_, isStage := stages[image]
+
+	// FROM image AS stage
+	if as := node.Next.Next; as != nil && strings.ToLower(as.Value) == "as" && as.Next != nil {
+		stages[as.Next.Value] = nil
+	}
+
+	// Referencing a previous build stage is always fine
+	if isStage {
+		return violations
+	}
+
+	named, err := reference.ParseNormalizedNamed(image)
+
+	if err != nil {
+		return append(violations, Violation{
+			Rule:        RULE_BASE_IMAGE,
+			Instruction: "FROM",
+			Line:        line,
+			Message:     "invalid base image " + image,
+			Hint:        "use a reference like name:tag",
+		})
+	}
+
+	if len(policy.AllowedRegistries) > 0 {
+		domain := reference.Domain(named)
+
+		if !isMatchingAny(policy.AllowedRegistries, domain) {
+			violations = append(violations, Violation{
+				Rule:        RULE_REGISTRY,
+				Instruction: "FROM",
+				Line:        line,
+				Message:     "registry " + domain + " is not allowed",
+				Hint:        "use an image from " + strings.Join(policy.AllowedRegistries, ", "),
+			})
+		}
+	}
+
+	if len(policy.AllowedBaseImages) > 0 {
+		familiarName := reference.FamiliarName(named)
+
+		if !isMatchingAny(policy.AllowedBaseImages, familiarName) && !isMatchingAny(policy.AllowedBaseImages, reference.Path(named)) {
+			violations = append(violations, Violation{
+				Rule:        RULE_BASE_IMAGE,
+				Instruction: "FROM",
+				Line:        line,
+				Message:     "base image " + familiarName + " is not allowed",
+				Hint:        "use one of " + strings.Join(policy.AllowedBaseImages, ", "),
+			})
+		}
+	}
+
+	return violations
+}
+
+func (policy Policy) checkRun(node *dockerfileparser.Node, line SourceLoc) []Violation {
+	violations := make([]Violation, 0)
+
+	// Shell form is a single node; exec form has one node per argument
+	args := make([]string, 0)
+	for next := node.Next; next != nil; next = next.Next {
+		args = append(args, next.Value)
+	}
+
+	command := strings.Join(args, " ")
+
+	for _, banned := range policy.BannedRunPatterns {
+		if banned.Pattern.MatchString(command) {
+			violations = append(violations, Violation{
+				Rule:        RULE_RUN_PATTERN,
+				Instruction: "RUN",
+				Line:        line,
+				Message:     "RUN command matches banned pattern " + banned.Pattern.String(),
+				Hint:        banned.Hint,
+			})
+		}
+	}
+
+	return violations
+}
+
+func (policy Policy) allowedInstructionsString() string {
+	instructions := make([]string, 0)
+
+	for instruction := range policy.AllowedInstructions {
+		instructions = append(instructions, instruction.String())
+	}
+
+	sort.Strings(instructions)
+
+	return strings.Join(instructions, ", ")
+}
+
+// ViolationsToError builds an error listing every violation, or nil if there
+// is none
+func ViolationsToError(violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	var chain error
+
+	for i := len(violations) - 1; i >= 0; i-- {
+		violation := violations[i]
+
+		berror := bettererrors.
+			New(violation.Message).
+			SetContext("rule", violation.Rule).
+			SetContext("line", violation.Line.String()).
+			SetContext("hint", violation.Hint)
+
+		if chain != nil {
+			berror.With(chain)
+		}
+
+		chain = berror
+	}
+
+	return bettererrors.
+		New("Dockerfile does not comply with the policy").
+		With(chain)
+}
+
+func isMatchingAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, value); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
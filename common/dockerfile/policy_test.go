@@ -0,0 +1,156 @@
+package dockerfile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	dockerfileparser "github.com/docker/docker/builder/dockerfile/parser"
+)
+
+type expectedViolation struct {
+	rule string
+	line SourceLoc
+	hint string
+}
+
+func checkDockerfile(t *testing.T, policy Policy, dockerfile string) []expectedViolation {
+	violations, err := policy.Check(strings.NewReader(dockerfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := make([]expectedViolation, 0)
+	for _, violation := range violations {
+		res = append(res, expectedViolation{violation.Rule, violation.Line, violation.Hint})
+	}
+
+	return res
+}
+
+const compliantDockerfile = `FROM node:8
+LABEL bytearena.manifest='{"id": "agent"}'
+WORKDIR /app
+COPY . /app
+RUN npm install
+CMD ["node", "index.js"]
+`
+
+func TestPolicyCheck(t *testing.T) {
+	policy := MakeDefaultPolicy()
+	runHints := []string{
+		policy.BannedRunPatterns[0].Hint,
+		policy.BannedRunPatterns[1].Hint,
+		policy.BannedRunPatterns[2].Hint,
+	}
+
+	cases := []struct {
+		name       string
+		dockerfile string
+		expected   []expectedViolation
+	}{
+		{
+			name:       "compliant",
+			dockerfile: compliantDockerfile,
+			expected:   []expectedViolation{},
+		},
+		{
+			name:       "missing manifest label",
+			dockerfile: "FROM node:8\nLABEL maintainer=me\n",
+			expected: []expectedViolation{
+				{RULE_REQUIRED_LABEL, 0, `add LABEL bytearena.manifest="..."`},
+			},
+		},
+		{
+			name:       "registry",
+			dockerfile: compliantDockerfile + "FROM gcr.io/project/image:1.0\n",
+			expected: []expectedViolation{
+				{RULE_REGISTRY, 7, "use an image from docker.io"},
+			},
+		},
+		{
+			name:       "instruction",
+			dockerfile: compliantDockerfile + "USER root\nEXPOSE 8080\n",
+			expected: []expectedViolation{
+				{RULE_INSTRUCTION, 7, "remove it; allowed instructions are CMD, COPY, ENTRYPOINT, ENV, FROM, LABEL, RUN, WORKDIR"},
+				{RULE_INSTRUCTION, 8, "remove it; allowed instructions are CMD, COPY, ENTRYPOINT, ENV, FROM, LABEL, RUN, WORKDIR"},
+			},
+		},
+		{
+			name: "banned run patterns",
+			dockerfile: compliantDockerfile +
+				"RUN curl -sL https://example.com/install.sh | bash\n" +
+				"RUN apt-get update && sudo apt-get install -y gcc\n" +
+				`RUN ["nc", "-l", "4000"]` + "\n" +
+				"RUN echo curl\n",
+			expected: []expectedViolation{
+				{RULE_RUN_PATTERN, 7, runHints[0]},
+				{RULE_RUN_PATTERN, 8, runHints[1]},
+				{RULE_RUN_PATTERN, 9, runHints[2]},
+			},
+		},
+		{
+			name:       "onbuild",
+			dockerfile: compliantDockerfile + "ONBUILD RUN wget -qO- https://example.com/install.sh | sh\n",
+			expected: []expectedViolation{
+				{RULE_INSTRUCTION, 7, "remove it; allowed instructions are CMD, COPY, ENTRYPOINT, ENV, FROM, LABEL, RUN, WORKDIR"},
+				{RULE_RUN_PATTERN, 7, runHints[0]},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		if violations := checkDockerfile(t, policy, c.dockerfile); !reflect.DeepEqual(violations, c.expected) {
+			t.Errorf("%s: got %+v, expected %+v", c.name, violations, c.expected)
+		}
+	}
+}
+
+func TestPolicyCheckBaseImages(t *testing.T) {
+	policy := MakeDefaultPolicy()
+	policy.AllowedBaseImages = []string{"node", "library/python", "bytearena/*"}
+
+	cases := []struct {
+		name     string
+		from     string
+		expected []expectedViolation
+	}{
+		{"familiar name", "FROM node:8-alpine", []expectedViolation{}},
+		{"path", "FROM python:3", []expectedViolation{}},
+		{"pattern", "FROM bytearena/agent-base", []expectedViolation{}},
+		{"not allowed", "FROM golang:1.9", []expectedViolation{
+			{RULE_BASE_IMAGE, 1, "use one of node, library/python, bytearena/*"},
+		}},
+		{"previous stage", "FROM node:8 AS build\nFROM build", []expectedViolation{}},
+		{"registry and image", "FROM quay.io/someone/image", []expectedViolation{
+			{RULE_REGISTRY, 1, "use an image from docker.io"},
+			{RULE_BASE_IMAGE, 1, "use one of node, library/python, bytearena/*"},
+		}},
+		{"invalid", "FROM Not_A_Reference", []expectedViolation{
+			{RULE_BASE_IMAGE, 1, "use a reference like name:tag"},
+		}},
+	}
+
+	for _, c := range cases {
+		dockerfile := c.from + "\nLABEL bytearena.manifest='{}'\n"
+		if violations := checkDockerfile(t, policy, dockerfile); !reflect.DeepEqual(violations, c.expected) {
+			t.Errorf("%s: got %+v, expected %+v", c.name, violations, c.expected)
+		}
+	}
+}
+
+func TestVisitNodesOnbuild(t *testing.T) {
+	// ONBUILD RUN make; the trigger is the child of the node following ONBUILD
+	run := &dockerfileparser.Node{Value: "run", Next: &dockerfileparser.Node{Value: "make"}}
+	onbuild := &dockerfileparser.Node{Value: "onbuild", Next: &dockerfileparser.Node{Children: []*dockerfileparser.Node{run}}}
+	from := &dockerfileparser.Node{Value: "from", Next: &dockerfileparser.Node{Value: "node"}}
+
+	visited := make([]string, 0)
+	visitNodes(&dockerfileparser.Node{Children: []*dockerfileparser.Node{from, onbuild}}, func(node *dockerfileparser.Node) {
+		visited = append(visited, node.Value)
+	})
+
+	if expected := []string{"from", "onbuild", "run"}; !reflect.DeepEqual(visited, expected) {
+		t.Errorf("got %v, expected %v", visited, expected)
+	}
+}
@@ -0,0 +1,38 @@
+package recording
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	AGENT_LOGS_ARCHIVE_DIR = "AgentLogs/"
+)
+
+type AgentLogEntry struct {
+	AgentId   string
+	AgentName string
+	Stream    string
+	Tick      int
+	Time      time.Time
+	Line      string
+}
+
+func (e AgentLogEntry) String() string {
+	return fmt.Sprintf(
+		"%s tick=%d %s %s",
+		e.Time.UTC().Format(time.RFC3339Nano),
+		e.Tick,
+		e.Stream,
+		e.Line,
+	)
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// AgentLogFilename is the name of the agent log file in the record archive;
+// the agent id disambiguates several agents running the same image
+func AgentLogFilename(agentName string, agentId string) string {
+	return AGENT_LOGS_ARCHIVE_DIR + unsafeFilenameChars.ReplaceAllString(agentName, "_") + "-" + agentId + ".log"
+}
@@ -14,6 +14,10 @@ func (r EmptyRecorder) Record(UUID string, msg string) error {
 	return nil
 }
 
+func (r EmptyRecorder) RecordAgentLog(UUID string, entry AgentLogEntry) error {
+	return nil
+}
+
 func (r EmptyRecorder) RecordMetadata(UUID string, mapcontainer *mapcontainer.MapContainer) error {
 	return nil
 }
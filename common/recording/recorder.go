@@ -18,6 +18,7 @@ type RecordMetadata struct {
 type RecorderInterface interface {
 	RecordMetadata(UUID string, mapcontainer *mapcontainer.MapContainer) error
 	Record(UUID string, msg string) error
+	RecordAgentLog(UUID string, entry AgentLogEntry) error
 	Close(UUID string)
 	Stop()
 	RecordStoreInterface
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/bytearena/core/common/types/mapcontainer"
@@ -15,6 +16,9 @@ type SingleArenaRecorder struct {
 	tempBaseFilename   string
 	recordFile         *os.File
 	recordMetadataFile *os.File
+
+	agentLogFiles      map[string]*os.File
+	agentLogFilesMutex *sync.Mutex
 }
 
 func MakeSingleArenaRecorder(filename string) *SingleArenaRecorder {
@@ -27,6 +31,9 @@ func MakeSingleArenaRecorder(filename string) *SingleArenaRecorder {
 		filename:         filename,
 		tempBaseFilename: tempBaseFilename,
 		recordFile:       f,
+
+		agentLogFiles:      make(map[string]*os.File),
+		agentLogFilesMutex: &sync.Mutex{},
 	}
 }
 
@@ -41,6 +48,16 @@ func (r *SingleArenaRecorder) Stop() {
 	if err != nil {
 		log.Println("Could not remove record temporary file: " + err.Error())
 	}
+
+	r.agentLogFilesMutex.Lock()
+	defer r.agentLogFilesMutex.Unlock()
+
+	for _, file := range r.agentLogFiles {
+		err = os.Remove(file.Name())
+		if err != nil {
+			log.Println("Could not remove agent log temporary file: " + err.Error())
+		}
+	}
 }
 
 func (r *SingleArenaRecorder) Close(UUID string) {
@@ -56,6 +73,15 @@ func (r *SingleArenaRecorder) Close(UUID string) {
 		Fd:   r.recordFile,
 	})
 
+	r.agentLogFilesMutex.Lock()
+	for name, file := range r.agentLogFiles {
+		files = append(files, ArchiveFile{
+			Name: name,
+			Fd:   file,
+		})
+	}
+	r.agentLogFilesMutex.Unlock()
+
 	err, _ := MakeArchive(r.filename, files)
 	utils.CheckWithFunc(err, func() string {
 		return "could not create record archive: " + err.Error()
@@ -63,6 +89,12 @@ func (r *SingleArenaRecorder) Close(UUID string) {
 
 	r.recordFile.Close()
 
+	r.agentLogFilesMutex.Lock()
+	for _, file := range r.agentLogFiles {
+		file.Close()
+	}
+	r.agentLogFilesMutex.Unlock()
+
 	utils.Debug("SingleArenaRecorder", "write record archive")
 }
 
@@ -102,6 +134,30 @@ func (r *SingleArenaRecorder) Record(UUID string, msg string) error {
 	return err
 }
 
+func (r *SingleArenaRecorder) RecordAgentLog(UUID string, entry AgentLogEntry) error {
+	name := AgentLogFilename(entry.AgentName, entry.AgentId)
+
+	r.agentLogFilesMutex.Lock()
+	defer r.agentLogFilesMutex.Unlock()
+
+	file, ok := r.agentLogFiles[name]
+
+	if !ok {
+		var err error
+		file, err = os.OpenFile(r.tempBaseFilename+".agent-"+entry.AgentId, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+		if err != nil {
+			return err
+		}
+
+		r.agentLogFiles[name] = file
+	}
+
+	_, err := file.WriteString(entry.String() + "\n")
+
+	return err
+}
+
 func (r *SingleArenaRecorder) GetFilePathForUUID(UUID string) string {
 	return ""
 }
@@ -6,13 +6,16 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"strings"
 
+	"github.com/bytearena/core/common/recording"
 	"github.com/bytearena/core/common/utils"
 )
 
 type rawRecordHandles struct {
 	recordMetadata io.ReadCloser
 	record         io.ReadCloser
+	agentLogs      map[string]*zip.File
 	zip            *zip.ReadCloser
 }
 
@@ -92,13 +95,40 @@ func (r *Replayer) Read() chan *ReplayMessage {
 	return r.streamingChannel
 }
 
+// ReadAgentLogs returns the logs printed by each agent during the game,
+// keyed by their name in the archive
+func (r *Replayer) ReadAgentLogs() (map[string]string, error) {
+	logs := make(map[string]string)
+
+	for name, file := range r.rawRecordHandles.agentLogs {
+		fd, err := file.Open()
+
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := ioutil.ReadAll(fd)
+		fd.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		logs[strings.TrimPrefix(name, recording.AGENT_LOGS_ARCHIVE_DIR)] = string(content)
+	}
+
+	return logs, nil
+}
+
 func (r *Replayer) Stop() {
 	utils.Debug("recorder", "stop replayer")
 	r.stopChannel <- true
 }
 
 func unzip(filename string) (error, *rawRecordHandles) {
-	rawRecordHandles := &rawRecordHandles{}
+	rawRecordHandles := &rawRecordHandles{
+		agentLogs: make(map[string]*zip.File),
+	}
 
 	reader, err := zip.OpenReader(filename)
 
@@ -109,6 +139,11 @@ func unzip(filename string) (error, *rawRecordHandles) {
 	rawRecordHandles.zip = reader
 
 	for _, file := range reader.File {
+		if strings.HasPrefix(file.Name, recording.AGENT_LOGS_ARCHIVE_DIR) {
+			rawRecordHandles.agentLogs[file.Name] = file
+			continue
+		}
+
 		fd, err := file.Open()
 
 		if err != nil {
@@ -2,30 +2,122 @@ package types
 
 import (
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"path"
+	"regexp"
+	"strings"
 
 	bettererrors "github.com/xtuc/better-errors"
 )
 
 type AgentManifest struct {
+	ManifestVersion int `json:"manifestversion,omitempty"`
+
 	Id   string `json:"id"`
 	Name string `json:"name"`
 
 	Author      string `json:"author"`
 	License     string `json:"license"`
 	Language    string `json:"language"`
-	GameMode    string `json:"gamemode"`
+	GameMode    string `json:"gamemode"` // v1 only, superseded by GameModes
 	RepoURL     string `json:"repourl"`
 	Description string `json:"description"`
 	AvatarURL   string `json:"avatarurl"`
+
+	// Capabilities, since v2
+	GameModes []string                `json:"gamemodes,omitempty"`
+	Protocols []string                `json:"protocols,omitempty"`
+	Encoding  string                  `json:"encoding,omitempty"`
+	Resources *AgentManifestResources `json:"resources,omitempty"`
+}
+
+type AgentManifestResources struct {
+	Cpus     float64 `json:"cpus,omitempty"`
+	MemoryMB int     `json:"memorymb,omitempty"`
 }
 
 const (
 	AGENT_MANIFEST_LABEL_KEY = "bytearena.manifest"
 	AGENT_MANIFEST_FILENAME  = "ba.json"
+
+	AGENT_MANIFEST_VERSION_1       = 1
+	AGENT_MANIFEST_VERSION_2       = 2
+	AGENT_MANIFEST_CURRENT_VERSION = AGENT_MANIFEST_VERSION_2
+
+	AGENT_ENCODING_JSON = "json"
 )
 
+var AGENT_ENCODINGS = []string{
+	AGENT_ENCODING_JSON,
+}
+
+func intPtr(v int) *int             { return &v }
+func float64Ptr(v float64) *float64 { return &v }
+
+func stringsToInterfaces(values []string) []interface{} {
+	res := make([]interface{}, len(values))
+	for i, v := range values {
+		res[i] = v
+	}
+
+	return res
+}
+
+var agentManifestCommonProperties = map[string]*JSONSchema{
+	"id":          {Type: "string", MinLength: intPtr(1)},
+	"name":        {Type: "string", MinLength: intPtr(1)},
+	"author":      {Type: "string"},
+	"license":     {Type: "string"},
+	"language":    {Type: "string"},
+	"gamemode":    {Type: "string"},
+	"repourl":     {Type: "string"},
+	"description": {Type: "string"},
+	"avatarurl":   {Type: "string"},
+}
+
+var agentManifestSchemaV1 = &JSONSchema{
+	Type:       "object",
+	Required:   []string{"id", "name"},
+	Properties: agentManifestCommonProperties,
+}
+
+var agentManifestSchemaV2 = func() *JSONSchema {
+	properties := map[string]*JSONSchema{
+		"manifestversion": {Type: "integer", Const: AGENT_MANIFEST_VERSION_2},
+		"gamemodes": {
+			Type:        "array",
+			MinItems:    intPtr(1),
+			UniqueItems: true,
+			Items:       &JSONSchema{Type: "string", Pattern: regexp.MustCompile(`^[a-z0-9]+(/[a-z0-9]+)?$`)},
+		},
+		"protocols": {
+			Type:        "array",
+			MinItems:    intPtr(1),
+			UniqueItems: true,
+			Items:       &JSONSchema{Type: "string", Enum: stringsToInterfaces(PROTOCOL_VERSIONS)},
+		},
+		"encoding": {Type: "string", Enum: stringsToInterfaces(AGENT_ENCODINGS)},
+		"resources": {
+			Type: "object",
+			Properties: map[string]*JSONSchema{
+				"cpus":     {Type: "number", Minimum: float64Ptr(0.1), Maximum: float64Ptr(4)},
+				"memorymb": {Type: "integer", Minimum: float64Ptr(16), Maximum: float64Ptr(2048)},
+			},
+		},
+	}
+
+	for name, property := range agentManifestCommonProperties {
+		properties[name] = property
+	}
+
+	return &JSONSchema{
+		Type:       "object",
+		Required:   []string{"manifestversion", "id", "name", "gamemodes", "protocols"},
+		Properties: properties,
+	}
+}()
+
 func GetAgentManifestByDockerImageName(
 	dockerImageName string,
 	orch ContainerOrchestrator,
@@ -66,9 +158,36 @@ func GetAgentManifestByDockerImageName(
 func ParseAgentManifestFromString(content []byte) (AgentManifest, error) {
 	var manifest AgentManifest
 
-	err := json.Unmarshal(content, &manifest)
+	err := validateAgentManifestJSON(content)
+
+	if err != nil {
+		return manifest, err
+	}
+
+	err = json.Unmarshal(content, &manifest)
 
-	return manifest, err
+	if err != nil {
+		return manifest, err
+	}
+
+	if manifest.ManifestVersion == 0 {
+		manifest.ManifestVersion = AGENT_MANIFEST_VERSION_1
+	}
+
+	return manifest, nil
+}
+
+// ParseAgentManifestFromReader reads a manifest from a stream, like stdin
+func ParseAgentManifestFromReader(reader io.Reader) (AgentManifest, error) {
+	content, err := ioutil.ReadAll(reader)
+
+	if err != nil {
+		return AgentManifest{}, bettererrors.
+			New("Could not read agent's manifest").
+			With(bettererrors.NewFromErr(err))
+	}
+
+	return ParseAgentManifestFromString(content)
 }
 
 func GetManifestLocation(dir string) string {
@@ -91,13 +210,135 @@ func ParseAgentManifestFromDir(dir string) (AgentManifest, error) {
 }
 
 func ValidateAgentManifest(manifest AgentManifest) error {
+	if manifest.ManifestVersion == AGENT_MANIFEST_VERSION_1 {
+		// Version 1 manifests do not carry their version
+		manifest.ManifestVersion = 0
+	}
+
+	content, err := json.Marshal(manifest)
+
+	if err != nil {
+		return err
+	}
+
+	return validateAgentManifestJSON(content)
+}
+
+func validateAgentManifestJSON(content []byte) error {
+	var header struct {
+		ManifestVersion json.Number `json:"manifestversion"`
+	}
+
+	if err := json.Unmarshal(content, &header); err != nil {
+		return bettererrors.
+			New("Invalid agent manifest").
+			With(bettererrors.NewFromErr(err))
+	}
+
+	schema := agentManifestSchemaV1
+
+	switch header.ManifestVersion.String() {
+	case "", "1":
+	case "2":
+		schema = agentManifestSchemaV2
+	default:
+		return bettererrors.
+			New("Unsupported agent manifest version").
+			SetContext("path", "$.manifestversion").
+			SetContext("version", header.ManifestVersion.String())
+	}
+
+	schemaErrors, err := ValidateJSONSchema(schema, content)
+
+	if err != nil {
+		return bettererrors.
+			New("Invalid agent manifest").
+			With(bettererrors.NewFromErr(err))
+	}
+
+	if len(schemaErrors) == 0 {
+		return nil
+	}
+
+	var chain error
+
+	for i := len(schemaErrors) - 1; i >= 0; i-- {
+		berror := bettererrors.
+			New(schemaErrors[i].Message).
+			SetContext("path", schemaErrors[i].Path)
+
+		if chain != nil {
+			berror.With(chain)
+		}
+
+		chain = berror
+	}
+
+	return bettererrors.
+		New("Invalid agent manifest").
+		With(chain)
+}
+
+// GetGameMode identifies the game played on a map, eg "deathmatch" or
+// "deathmatch/maze"; agents declare the modes they support in their manifest
+func GetGameMode(gameDescription GameDescriptionInterface) string {
+	meta := gameDescription.GetMapContainer().Meta
+
+	if meta.Variant == "" {
+		return meta.Kind
+	}
+
+	return meta.Kind + "/" + meta.Variant
+}
+
+// CheckAgentManifestCompatibility tells whether the agent can take part in
+// a game of the given mode. Version 1 manifests declare no capabilities and
+// are always accepted.
+func CheckAgentManifestCompatibility(manifest AgentManifest, gameMode string) error {
+	if manifest.ManifestVersion < AGENT_MANIFEST_VERSION_2 {
+		return nil
+	}
+
+	gameKind := strings.SplitN(gameMode, "/", 2)[0]
+	supportsGameMode := false
+
+	for _, mode := range manifest.GameModes {
+		if mode == gameMode || mode == gameKind {
+			supportsGameMode = true
+			break
+		}
+	}
+
+	if !supportsGameMode {
+		return bettererrors.
+			New("Agent does not support the game mode").
+			SetContext("agent", manifest.Id).
+			SetContext("game mode", gameMode).
+			SetContext("supported game modes", strings.Join(manifest.GameModes, ", "))
+	}
+
+	supportsProtocol := false
+
+	for _, protocol := range manifest.Protocols {
+		for _, serverProtocol := range PROTOCOL_VERSIONS {
+			if protocol == serverProtocol {
+				supportsProtocol = true
+			}
+		}
+	}
 
-	if manifest.Id == "" {
-		return bettererrors.New("Missing id")
+	if !supportsProtocol {
+		return bettererrors.
+			New("Agent does not support any protocol of this server").
+			SetContext("agent", manifest.Id).
+			SetContext("protocols", strings.Join(manifest.Protocols, ", "))
 	}
 
-	if manifest.Name == "" {
-		return bettererrors.New("Missing name")
+	if manifest.Encoding != "" && manifest.Encoding != AGENT_ENCODING_JSON {
+		return bettererrors.
+			New("Agent encoding is not supported").
+			SetContext("agent", manifest.Id).
+			SetContext("encoding", manifest.Encoding)
 	}
 
 	return nil
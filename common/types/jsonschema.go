@@ -0,0 +1,184 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONSchema is the subset of JSON Schema used to validate documents
+// written by users, like the agent manifest
+type JSONSchema struct {
+	Type       string // object, array, string, number, integer, boolean
+	Required   []string
+	Properties map[string]*JSONSchema
+	Items      *JSONSchema
+	Enum       []interface{}
+	Const      interface{}
+
+	MinLength *int
+	Pattern   *regexp.Regexp
+	MinItems  *int
+	Minimum   *float64
+	Maximum   *float64
+
+	UniqueItems bool
+}
+
+// JSONSchemaError points at the offending value with a path like $.protocols[1]
+type JSONSchemaError struct {
+	Path    string
+	Message string
+}
+
+func (e JSONSchemaError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+func ValidateJSONSchema(schema *JSONSchema, content []byte) ([]JSONSchemaError, error) {
+	var document interface{}
+
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return schema.validate("$", document), nil
+}
+
+func (schema *JSONSchema) validate(path string, value interface{}) []JSONSchemaError {
+	errs := make([]JSONSchemaError, 0)
+
+	fail := func(format string, args ...interface{}) []JSONSchemaError {
+		return append(errs, JSONSchemaError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !schema.hasType(value) {
+		return fail("expected %s, got %s", schema.Type, jsonTypeOf(value))
+	}
+
+	if schema.Const != nil && fmt.Sprint(value) != fmt.Sprint(schema.Const) {
+		return fail("must be %v", schema.Const)
+	}
+
+	if len(schema.Enum) > 0 {
+		found := false
+		for _, allowed := range schema.Enum {
+			if fmt.Sprint(value) == fmt.Sprint(allowed) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fail("must be one of %v", schema.Enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		if schema.MinLength != nil && len(v) < *schema.MinLength {
+			errs = fail("must be at least %d characters long", *schema.MinLength)
+		}
+
+		if schema.Pattern != nil && !schema.Pattern.MatchString(v) {
+			errs = fail("must match %s", schema.Pattern.String())
+		}
+
+	case json.Number:
+		n, _ := v.Float64()
+
+		if schema.Minimum != nil && n < *schema.Minimum {
+			errs = fail("must be >= %v", *schema.Minimum)
+		}
+
+		if schema.Maximum != nil && n > *schema.Maximum {
+			errs = fail("must be <= %v", *schema.Maximum)
+		}
+
+	case []interface{}:
+		if schema.MinItems != nil && len(v) < *schema.MinItems {
+			errs = fail("must have at least %d items", *schema.MinItems)
+		}
+
+		seen := make(map[string]interface{})
+
+		for i, item := range v {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+
+			if schema.Items != nil {
+				errs = append(errs, schema.Items.validate(itemPath, item)...)
+			}
+
+			if schema.UniqueItems {
+				key := fmt.Sprint(item)
+				if _, duplicate := seen[key]; duplicate {
+					errs = append(errs, JSONSchemaError{Path: itemPath, Message: "duplicate item " + key})
+				}
+				seen[key] = nil
+			}
+		}
+
+	case map[string]interface{}:
+		for _, property := range schema.Required {
+			if _, found := v[property]; !found {
+				errs = append(errs, JSONSchemaError{Path: path + "." + property, Message: "is required"})
+			}
+		}
+
+		// Sorted for stable error ordering
+		properties := make([]string, 0, len(v))
+		for property := range v {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+
+		for _, property := range properties {
+			if propertySchema, ok := schema.Properties[property]; ok {
+				errs = append(errs, propertySchema.validate(path+"."+property, v[property])...)
+			}
+		}
+	}
+
+	return errs
+}
+
+func (schema *JSONSchema) hasType(value interface{}) bool {
+	actual := jsonTypeOf(value)
+
+	switch schema.Type {
+	case "":
+		return true
+	case "number":
+		return actual == "number" || actual == "integer"
+	}
+
+	return actual == schema.Type
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,139 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+const validManifestV2 = `{
+	"manifestversion": 2,
+	"id": "agent",
+	"name": "Agent",
+	"gamemodes": ["deathmatch", "deathmatch/maze"],
+	"protocols": ["clear_v1"],
+	"encoding": "json",
+	"resources": {"cpus": 0.5, "memorymb": 128}
+}`
+
+func TestValidateJSONSchemaPaths(t *testing.T) {
+	cases := []struct {
+		name     string
+		manifest string
+		paths    []string
+	}{
+		{
+			name:     "valid",
+			manifest: validManifestV2,
+			paths:    []string{},
+		},
+		{
+			name:     "required",
+			manifest: `{"manifestversion": 2, "name": "Agent", "gamemodes": ["deathmatch"]}`,
+			paths:    []string{"$.id", "$.protocols"},
+		},
+		{
+			name:     "type",
+			manifest: `{"manifestversion": 2, "id": 42, "name": "Agent", "gamemodes": "deathmatch", "protocols": ["clear_v1"]}`,
+			paths:    []string{"$.gamemodes", "$.id"},
+		},
+		{
+			name:     "const",
+			manifest: `{"manifestversion": 3, "id": "agent", "name": "Agent", "gamemodes": ["deathmatch"], "protocols": ["clear_v1"]}`,
+			paths:    []string{"$.manifestversion"},
+		},
+		{
+			name:     "pattern",
+			manifest: `{"manifestversion": 2, "id": "agent", "name": "Agent", "gamemodes": ["deathmatch", "Death Match"], "protocols": ["clear_v1"]}`,
+			paths:    []string{"$.gamemodes[1]"},
+		},
+		{
+			name:     "enum",
+			manifest: `{"manifestversion": 2, "id": "agent", "name": "Agent", "gamemodes": ["deathmatch"], "protocols": ["clear_v0", "clear_v1"], "encoding": "xml"}`,
+			paths:    []string{"$.encoding", "$.protocols[0]"},
+		},
+		{
+			name:     "uniqueItems",
+			manifest: `{"manifestversion": 2, "id": "agent", "name": "Agent", "gamemodes": ["deathmatch"], "protocols": ["clear_v1", "clear_beta", "clear_v1"]}`,
+			paths:    []string{"$.protocols[2]"},
+		},
+		{
+			name:     "minItems",
+			manifest: `{"manifestversion": 2, "id": "agent", "name": "Agent", "gamemodes": [], "protocols": ["clear_v1"]}`,
+			paths:    []string{"$.gamemodes"},
+		},
+		{
+			name:     "minLength",
+			manifest: `{"manifestversion": 2, "id": "", "name": "Agent", "gamemodes": ["deathmatch"], "protocols": ["clear_v1"]}`,
+			paths:    []string{"$.id"},
+		},
+		{
+			name:     "minimum and maximum",
+			manifest: `{"manifestversion": 2, "id": "agent", "name": "Agent", "gamemodes": ["deathmatch"], "protocols": ["clear_v1"], "resources": {"cpus": 8, "memorymb": 1}}`,
+			paths:    []string{"$.resources.cpus", "$.resources.memorymb"},
+		},
+		{
+			name:     "integer",
+			manifest: `{"manifestversion": 2, "id": "agent", "name": "Agent", "gamemodes": ["deathmatch"], "protocols": ["clear_v1"], "resources": {"memorymb": 128.5}}`,
+			paths:    []string{"$.resources.memorymb"},
+		},
+	}
+
+	for _, c := range cases {
+		schemaErrors, err := ValidateJSONSchema(agentManifestSchemaV2, []byte(c.manifest))
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+
+		paths := make([]string, 0)
+		for _, schemaError := range schemaErrors {
+			paths = append(paths, schemaError.Path)
+		}
+
+		if !reflect.DeepEqual(paths, c.paths) {
+			t.Errorf("%s: got %v, expected %v (%v)", c.name, paths, c.paths, schemaErrors)
+		}
+	}
+}
+
+func TestValidateJSONSchemaMalformed(t *testing.T) {
+	if _, err := ValidateJSONSchema(agentManifestSchemaV2, []byte(`{"id": `)); err == nil {
+		t.Error("expected a syntax error")
+	}
+}
+
+func TestParseAgentManifestVersions(t *testing.T) {
+	// v1 manifests do not carry their version
+	manifest, err := ParseAgentManifestFromString([]byte(`{"id": "agent", "name": "Agent", "gamemode": "deathmatch"}`))
+	if err != nil {
+		t.Fatalf("v1: %s", err)
+	}
+
+	if manifest.ManifestVersion != AGENT_MANIFEST_VERSION_1 {
+		t.Errorf("v1: got version %d, expected %d", manifest.ManifestVersion, AGENT_MANIFEST_VERSION_1)
+	}
+
+	if err := ValidateAgentManifest(manifest); err != nil {
+		t.Errorf("v1: defaulted manifest does not validate: %s", err)
+	}
+
+	// v1 manifests are checked against the v1 schema only
+	if _, err := ParseAgentManifestFromString([]byte(`{"name": "Agent"}`)); err == nil {
+		t.Error("v1: expected the missing id to be reported")
+	}
+
+	manifest, err = ParseAgentManifestFromString([]byte(validManifestV2))
+	if err != nil {
+		t.Fatalf("v2: %s", err)
+	}
+
+	if manifest.ManifestVersion != AGENT_MANIFEST_VERSION_2 || !reflect.DeepEqual(manifest.Protocols, []string{"clear_v1"}) {
+		t.Errorf("v2: got %+v", manifest)
+	}
+
+	for _, version := range []string{"3", "0.5", `"2"`} {
+		if _, err := ParseAgentManifestFromString([]byte(`{"manifestversion": ` + version + `, "id": "agent", "name": "Agent"}`)); err == nil {
+			t.Errorf("version %s: expected an unsupported version error", version)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package mapcontainer
 
 import (
+	"github.com/bytearena/core/common/utils/space"
 	"github.com/bytearena/core/common/utils/vector"
 )
 
@@ -38,6 +39,10 @@ func (m MapPoint) GetY() float64 {
 	return m[1]
 }
 
+func (m MapPoint) ToMapVector2() space.MapVector2 {
+	return space.MakeMapVector2(m.GetX(), m.GetY())
+}
+
 type MapPolygon struct {
 	Points []MapPoint `json:"points"`
 }
@@ -63,4 +68,18 @@ type MapPolygonObject struct {
 	Name    string     `json:"name"`
 	Polygon MapPolygon `json:"polygon"`
 	Tags    []string   `json:"tags,omitempty"`
+	Motion  *MapMotion `json:"motion,omitempty"` // obstacles only; nil for static obstacles
+}
+
+// Scripted motion of a kinematic obstacle, in map coordinates
+type MapMotion struct {
+	Pivot *MapPoint `json:"pivot,omitempty"` // origin of the obstacle; defaults to the centroid of its polygon
+
+	Path  []MapPoint `json:"path,omitempty"`  // waypoints followed by the pivot, starting from its position in the map
+	Speed float64    `json:"speed,omitempty"` // along the path, in map units per tick
+	Loop  bool       `json:"loop,omitempty"`  // goes back to the start after the last waypoint; back and forth otherwise
+
+	AngularSpeed float64 `json:"angularspeed,omitempty"` // around the pivot, in radian per tick
+	RotateFor    int     `json:"rotatefor,omitempty"`    // rotation schedule, in ticks: rotates for RotateFor, then pauses for PauseFor; 0 => rotates continuously
+	PauseFor     int     `json:"pausefor,omitempty"`
 }
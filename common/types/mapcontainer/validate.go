@@ -0,0 +1,174 @@
+package mapcontainer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bytearena/core/common/utils/polygon"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+const geometryTolerance = 0.005 // Box2D linear slop; shorter edges are degenerate
+
+var MapGeometryIssueKind = struct {
+	TooFewPoints     string
+	DegenerateEdge   string
+	Winding          string
+	SelfIntersection string
+	Hole             string
+}{
+	TooFewPoints:     "toofewpoints",
+	DegenerateEdge:   "degenerateedge",
+	Winding:          "winding",
+	SelfIntersection: "selfintersection",
+	Hole:             "hole",
+}
+
+type MapGeometryIssue struct {
+	Kind     string
+	Layer    string // grounds, obstacles or otherpolygons
+	Index    int    // in the layer
+	Id       string
+	Name     string
+	Details  string
+	Repaired bool
+	Skipped  bool // cannot be repaired; the polygon is emptied, and not loaded
+}
+
+func (issue MapGeometryIssue) String() string {
+	res := issue.Layer + "[" + strconv.Itoa(issue.Index) + "]"
+	if issue.Name != "" {
+		res += " (" + issue.Name + ")"
+	}
+
+	res += ": " + issue.Kind
+	if issue.Details != "" {
+		res += "; " + issue.Details
+	}
+
+	if issue.Repaired {
+		res += " (repaired)"
+	}
+
+	if issue.Skipped {
+		res += " (skipped)"
+	}
+
+	return res
+}
+
+// Polygons too small to be loaded; reported as TooFewPoints
+func (p MapPolygon) IsUsable() bool {
+	return len(p.Points) >= 3
+}
+
+func makeMapPolygonFromVector2Array(points []vector.Vector2) MapPolygon {
+	res := MapPolygon{
+		Points: make([]MapPoint, len(points)),
+	}
+
+	for i, point := range points {
+		res.Points[i] = MakeMapPointFromVector2(point)
+	}
+
+	return res
+}
+
+// Checks the polygons of the map, and repairs them in place when possible:
+// repeated points are removed, and every polygon is made CCW (map coordinates)
+// Polygons that cannot be repaired (too few points, self-intersections, solid holes) are emptied, so that they are not loaded
+func (m *MapContainer) ValidateGeometry() []MapGeometryIssue {
+	issues := make([]MapGeometryIssue, 0)
+
+	layers := []struct {
+		name    string
+		objects []MapPolygonObject
+		solid   bool // other polygons are zones, which may be nested
+	}{
+		{"grounds", m.Data.Grounds, true},
+		{"obstacles", m.Data.Obstacles, true},
+		{"otherpolygons", m.Data.OtherPolygonObjects, false},
+	}
+
+	for _, layer := range layers {
+		simple := make([]bool, len(layer.objects))
+
+		for i := range layer.objects {
+			object := &layer.objects[i]
+
+			issue := func(kind string, details string, repaired bool) {
+				issues = append(issues, MapGeometryIssue{
+					Kind:     kind,
+					Layer:    layer.name,
+					Index:    i,
+					Id:       object.Id,
+					Name:     object.Name,
+					Details:  details,
+					Repaired: repaired,
+					Skipped:  !repaired,
+				})
+			}
+
+			points, removed := polygon.RemoveDegenerateEdges(object.Polygon.ToVector2Array(), geometryTolerance)
+			if removed > 0 {
+				issue(MapGeometryIssueKind.DegenerateEdge, strconv.Itoa(removed)+" repeated point(s) removed", true)
+			}
+
+			if len(points) < 3 {
+				issue(MapGeometryIssueKind.TooFewPoints, strconv.Itoa(len(points))+" distinct point(s)", false)
+				object.Polygon = MapPolygon{}
+				continue
+			}
+
+			if polygon.IsCW(polygon.GetOpenPolygonWinding(points)) {
+				points = polygon.InvertWinding(points)
+				issue(MapGeometryIssueKind.Winding, "made counter-clockwise", true)
+			}
+
+			if intersections := polygon.FindSelfIntersections(points); len(intersections) > 0 {
+				issue(MapGeometryIssueKind.SelfIntersection, fmt.Sprintf("edges %d and %d cross", intersections[0][0], intersections[0][1]), false)
+				object.Polygon = MapPolygon{}
+				continue
+			}
+
+			object.Polygon = makeMapPolygonFromVector2Array(points)
+
+			simple[i] = true
+		}
+
+		// Polygons nested in another polygon of the same layer
+		holes := make([]int, 0)
+		for i := range layer.objects {
+			for j := range layer.objects {
+				if i == j || !simple[i] || !simple[j] {
+					continue
+				}
+
+				if polygon.ContainsPolygon(layer.objects[j].Polygon.ToVector2Array(), layer.objects[i].Polygon.ToVector2Array()) {
+					issues = append(issues, MapGeometryIssue{
+						Kind:    MapGeometryIssueKind.Hole,
+						Layer:   layer.name,
+						Index:   i,
+						Id:      layer.objects[i].Id,
+						Name:    layer.objects[i].Name,
+						Details: "inside " + layer.name + "[" + strconv.Itoa(j) + "]",
+						Skipped: layer.solid,
+					})
+
+					if layer.solid {
+						holes = append(holes, i)
+					}
+
+					break
+				}
+			}
+		}
+
+		// emptied once all are found, so that a hole does not hide the next ones
+		for _, i := range holes {
+			layer.objects[i].Polygon = MapPolygon{}
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,97 @@
+package mapcontainer
+
+import (
+	"testing"
+)
+
+func makeMapPolygonObject(name string, points ...MapPoint) MapPolygonObject {
+	return MapPolygonObject{
+		Name:    name,
+		Polygon: MapPolygon{Points: points},
+	}
+}
+
+func countIssues(issues []MapGeometryIssue, kind string, name string) int {
+	count := 0
+	for _, issue := range issues {
+		if issue.Kind == kind && issue.Name == name {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestValidateGeometry(t *testing.T) {
+	arenaMap := &MapContainer{}
+
+	arenaMap.Data.Grounds = []MapPolygonObject{
+		makeMapPolygonObject("ground", MapPoint{0, 0}, MapPoint{100, 0}, MapPoint{100, 100}, MapPoint{0, 100}),
+	}
+
+	arenaMap.Data.Obstacles = []MapPolygonObject{
+		// closed, clockwise, with a repeated point
+		makeMapPolygonObject("closed", MapPoint{10, 10}, MapPoint{10, 20}, MapPoint{10, 20}, MapPoint{20, 20}, MapPoint{20, 10}, MapPoint{10, 10}),
+		makeMapPolygonObject("bowtie", MapPoint{30, 30}, MapPoint{40, 40}, MapPoint{40, 30}, MapPoint{30, 40}),
+		makeMapPolygonObject("flat", MapPoint{50, 50}, MapPoint{50, 50}),
+		makeMapPolygonObject("nested", MapPoint{12, 12}, MapPoint{14, 12}, MapPoint{14, 14}),
+	}
+
+	issues := arenaMap.ValidateGeometry()
+
+	if countIssues(issues, MapGeometryIssueKind.DegenerateEdge, "closed") != 1 || countIssues(issues, MapGeometryIssueKind.Winding, "closed") != 1 {
+		t.Errorf("closed: %v", issues)
+	}
+
+	if closed := arenaMap.Data.Obstacles[0].Polygon; len(closed.Points) != 4 {
+		t.Errorf("closed: %d points after repair, expected 4", len(closed.Points))
+	}
+
+	if countIssues(issues, MapGeometryIssueKind.SelfIntersection, "bowtie") != 1 || arenaMap.Data.Obstacles[1].Polygon.IsUsable() {
+		t.Errorf("bowtie: %v", issues)
+	}
+
+	if countIssues(issues, MapGeometryIssueKind.TooFewPoints, "flat") != 1 || arenaMap.Data.Obstacles[2].Polygon.IsUsable() {
+		t.Errorf("flat: %v", issues)
+	}
+
+	if countIssues(issues, MapGeometryIssueKind.Hole, "nested") != 1 || arenaMap.Data.Obstacles[3].Polygon.IsUsable() {
+		t.Errorf("nested: %v", issues)
+	}
+
+	// what cannot be repaired is not loaded
+	for _, issue := range issues {
+		if issue.Skipped == issue.Repaired {
+			t.Errorf("%s: expected either repaired or skipped", issue.String())
+		}
+	}
+
+	for _, issue := range issues {
+		if issue.Name == "ground" {
+			t.Errorf("ground: unexpected issue %s", issue.String())
+		}
+	}
+
+	// Repaired map is clean, except for what cannot be repaired
+	for _, issue := range arenaMap.ValidateGeometry() {
+		if issue.Repaired {
+			t.Errorf("second pass: unexpected repair %s", issue.String())
+		}
+	}
+}
+
+func TestValidateGeometryNestedZones(t *testing.T) {
+	arenaMap := &MapContainer{}
+
+	// zones may be nested: reported, but loaded
+	arenaMap.Data.OtherPolygonObjects = []MapPolygonObject{
+		makeMapPolygonObject("zone", MapPoint{0, 0}, MapPoint{0, 100}, MapPoint{100, 100}, MapPoint{100, 0}),
+		makeMapPolygonObject("base", MapPoint{10, 10}, MapPoint{10, 20}, MapPoint{20, 20}, MapPoint{20, 10}),
+	}
+
+	issues := arenaMap.ValidateGeometry()
+
+	if countIssues(issues, MapGeometryIssueKind.Hole, "base") != 1 || !arenaMap.Data.OtherPolygonObjects[1].Polygon.IsUsable() {
+		t.Errorf("base: %v", issues)
+	}
+}
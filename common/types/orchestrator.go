@@ -14,6 +14,9 @@ type ContainerOrchestrator interface {
 	Wait(ctner *AgentContainer) (<-chan container.ContainerWaitOKBody, <-chan error)
 	TearDown(container *AgentContainer)
 	CreateAgentContainer(agentid uuid.UUID, host string, port int, dockerimage string) (*AgentContainer, error)
+	DiscardAgentContainer(ctner *AgentContainer) error
+	PullImages(dockerimages []string) error
+	BuildAgentImage(dir string, dockerimage string, labels map[string]string) error
 	GetHost() (string, error)
 	SetAgentLogger(container *AgentContainer) error
 	TearDownAll() error
@@ -14,12 +14,16 @@ var PhysicalBodyDescriptorType = struct {
 	Ground     string
 	Projectile string
 	Sensor     string
+	Pickup     string
+	Flag       string
 }{
 	Obstacle:   "o",
 	Agent:      "a",
 	Ground:     "g",
 	Projectile: "p",
 	Sensor:     "s",
+	Pickup:     "k",
+	Flag:       "f",
 }
 
 func MakePhysicalBodyDescriptor(type_ string, id ecs.EntityID) PhysicalBodyDescriptor {
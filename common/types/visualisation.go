@@ -7,6 +7,7 @@ import (
 type VizMessage struct {
 	GameID        string
 	Objects       []VizMessageObject
+	Obstacles     []VizMessageObstacle
 	DebugPoints   [][2]float64
 	DebugSegments [][2][2]float64
 	Events        []VizMessageEvent
@@ -23,6 +24,16 @@ type VizMessageObject struct {
 	PlayerInfo *PlayerInfo
 }
 
+// State of a destructible or moving obstacle of the map
+type VizMessageObstacle struct {
+	Id        string  // id of the obstacle in the map
+	Life      float64 // 0 if not destructible
+	MaxLife   float64 // 0 if not destructible
+	Destroyed bool
+
+	Points []vector.Vector2 // current outline of moving obstacles, physical referential; nil if static
+}
+
 type PlayerInfo struct {
 	IsAlive    bool
 	PlayerId   string
@@ -4,6 +4,7 @@ package types
 
 import (
 	json "encoding/json"
+	_vector "github.com/bytearena/core/common/utils/vector"
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
@@ -54,12 +55,7 @@ func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes(out *jwriter.Writer
 	_ = first
 	{
 		const prefix string = ",\"Value\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix[1:])
 		out.Int(int(in.Value))
 	}
 	out.RawByte('}')
@@ -88,7 +84,155 @@ func (v *VizMessagePlayerScore) UnmarshalJSON(data []byte) error {
 func (v *VizMessagePlayerScore) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes(l, v)
 }
-func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes1(in *jlexer.Lexer, out *VizMessageObject) {
+func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes1(in *jlexer.Lexer, out *VizMessageObstacle) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Id":
+			out.Id = string(in.String())
+		case "Life":
+			out.Life = float64(in.Float64())
+		case "MaxLife":
+			out.MaxLife = float64(in.Float64())
+		case "Destroyed":
+			out.Destroyed = bool(in.Bool())
+		case "Points":
+			if in.IsNull() {
+				in.Skip()
+				out.Points = nil
+			} else {
+				in.Delim('[')
+				if out.Points == nil {
+					if !in.IsDelim(']') {
+						out.Points = make([]_vector.Vector2, 0, 4)
+					} else {
+						out.Points = []_vector.Vector2{}
+					}
+				} else {
+					out.Points = (out.Points)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 _vector.Vector2
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('[')
+						v2 := 0
+						for !in.IsDelim(']') {
+							if v2 < 2 {
+								(v1)[v2] = float64(in.Float64())
+								v2++
+							} else {
+								in.SkipRecursive()
+							}
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					out.Points = append(out.Points, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes1(out *jwriter.Writer, in VizMessageObstacle) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Id))
+	}
+	{
+		const prefix string = ",\"Life\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Life))
+	}
+	{
+		const prefix string = ",\"MaxLife\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.MaxLife))
+	}
+	{
+		const prefix string = ",\"Destroyed\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Destroyed))
+	}
+	{
+		const prefix string = ",\"Points\":"
+		out.RawString(prefix)
+		if in.Points == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v3, v4 := range in.Points {
+				if v3 > 0 {
+					out.RawByte(',')
+				}
+				out.RawByte('[')
+				for v5 := range v4 {
+					if v5 > 0 {
+						out.RawByte(',')
+					}
+					out.Float64(float64((v4)[v5]))
+				}
+				out.RawByte(']')
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VizMessageObstacle) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VizMessageObstacle) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VizMessageObstacle) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VizMessageObstacle) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes1(l, v)
+}
+func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes2(in *jlexer.Lexer, out *VizMessageObject) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -116,11 +260,11 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes1(in *jlexer.Lexer,
 				in.Skip()
 			} else {
 				in.Delim('[')
-				v1 := 0
+				v6 := 0
 				for !in.IsDelim(']') {
-					if v1 < 2 {
-						out.Position[v1] = float64(in.Float64())
-						v1++
+					if v6 < 2 {
+						(out.Position)[v6] = float64(in.Float64())
+						v6++
 					} else {
 						in.SkipRecursive()
 					}
@@ -133,11 +277,11 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes1(in *jlexer.Lexer,
 				in.Skip()
 			} else {
 				in.Delim('[')
-				v2 := 0
+				v7 := 0
 				for !in.IsDelim(']') {
-					if v2 < 2 {
-						out.Velocity[v2] = float64(in.Float64())
-						v2++
+					if v7 < 2 {
+						(out.Velocity)[v7] = float64(in.Float64())
+						v7++
 					} else {
 						in.SkipRecursive()
 					}
@@ -169,92 +313,57 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes1(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes1(out *jwriter.Writer, in VizMessageObject) {
+func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes2(out *jwriter.Writer, in VizMessageObject) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
 		const prefix string = ",\"Id\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix[1:])
 		out.String(string(in.Id))
 	}
 	{
 		const prefix string = ",\"Type\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.String(string(in.Type))
 	}
 	{
 		const prefix string = ",\"Position\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.RawByte('[')
-		for v3 := range in.Position {
-			if v3 > 0 {
+		for v8 := range in.Position {
+			if v8 > 0 {
 				out.RawByte(',')
 			}
-			out.Float64(float64(in.Position[v3]))
+			out.Float64(float64((in.Position)[v8]))
 		}
 		out.RawByte(']')
 	}
 	{
 		const prefix string = ",\"Velocity\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.RawByte('[')
-		for v4 := range in.Velocity {
-			if v4 > 0 {
+		for v9 := range in.Velocity {
+			if v9 > 0 {
 				out.RawByte(',')
 			}
-			out.Float64(float64(in.Velocity[v4]))
+			out.Float64(float64((in.Velocity)[v9]))
 		}
 		out.RawByte(']')
 	}
 	{
 		const prefix string = ",\"Radius\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.Float64(float64(in.Radius))
 	}
 	{
 		const prefix string = ",\"Orientation\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.Float64(float64(in.Orientation))
 	}
 	{
 		const prefix string = ",\"PlayerInfo\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		if in.PlayerInfo == nil {
 			out.RawString("null")
 		} else {
@@ -267,27 +376,27 @@ func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes1(out *jwriter.Write
 // MarshalJSON supports json.Marshaler interface
 func (v VizMessageObject) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes1(&w, v)
+	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v VizMessageObject) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes1(w, v)
+	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *VizMessageObject) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes1(&r, v)
+	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *VizMessageObject) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes1(l, v)
+	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes2(l, v)
 }
-func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes2(in *jlexer.Lexer, out *VizMessageEvent) {
+func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer, out *VizMessageEvent) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -326,28 +435,18 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes2(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes2(out *jwriter.Writer, in VizMessageEvent) {
+func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes3(out *jwriter.Writer, in VizMessageEvent) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
 		const prefix string = ",\"Subject\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix[1:])
 		out.String(string(in.Subject))
 	}
 	{
 		const prefix string = ",\"Payload\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		if m, ok := in.Payload.(easyjson.Marshaler); ok {
 			m.MarshalEasyJSON(out)
 		} else if m, ok := in.Payload.(json.Marshaler); ok {
@@ -362,27 +461,27 @@ func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes2(out *jwriter.Write
 // MarshalJSON supports json.Marshaler interface
 func (v VizMessageEvent) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes2(&w, v)
+	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v VizMessageEvent) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes2(w, v)
+	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *VizMessageEvent) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes2(&r, v)
+	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *VizMessageEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes2(l, v)
+	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(l, v)
 }
-func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer, out *VizMessage) {
+func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes4(in *jlexer.Lexer, out *VizMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -411,7 +510,7 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer,
 				in.Delim('[')
 				if out.Objects == nil {
 					if !in.IsDelim(']') {
-						out.Objects = make([]VizMessageObject, 0, 1)
+						out.Objects = make([]VizMessageObject, 0, 0)
 					} else {
 						out.Objects = []VizMessageObject{}
 					}
@@ -419,9 +518,32 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer,
 					out.Objects = (out.Objects)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v5 VizMessageObject
-					(v5).UnmarshalEasyJSON(in)
-					out.Objects = append(out.Objects, v5)
+					var v10 VizMessageObject
+					(v10).UnmarshalEasyJSON(in)
+					out.Objects = append(out.Objects, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "Obstacles":
+			if in.IsNull() {
+				in.Skip()
+				out.Obstacles = nil
+			} else {
+				in.Delim('[')
+				if out.Obstacles == nil {
+					if !in.IsDelim(']') {
+						out.Obstacles = make([]VizMessageObstacle, 0, 1)
+					} else {
+						out.Obstacles = []VizMessageObstacle{}
+					}
+				} else {
+					out.Obstacles = (out.Obstacles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 VizMessageObstacle
+					(v11).UnmarshalEasyJSON(in)
+					out.Obstacles = append(out.Obstacles, v11)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -442,16 +564,16 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer,
 					out.DebugPoints = (out.DebugPoints)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v6 [2]float64
+					var v12 [2]float64
 					if in.IsNull() {
 						in.Skip()
 					} else {
 						in.Delim('[')
-						v7 := 0
+						v13 := 0
 						for !in.IsDelim(']') {
-							if v7 < 2 {
-								v6[v7] = float64(in.Float64())
-								v7++
+							if v13 < 2 {
+								(v12)[v13] = float64(in.Float64())
+								v13++
 							} else {
 								in.SkipRecursive()
 							}
@@ -459,7 +581,7 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer,
 						}
 						in.Delim(']')
 					}
-					out.DebugPoints = append(out.DebugPoints, v6)
+					out.DebugPoints = append(out.DebugPoints, v12)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -480,23 +602,23 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer,
 					out.DebugSegments = (out.DebugSegments)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v8 [2][2]float64
+					var v14 [2][2]float64
 					if in.IsNull() {
 						in.Skip()
 					} else {
 						in.Delim('[')
-						v9 := 0
+						v15 := 0
 						for !in.IsDelim(']') {
-							if v9 < 2 {
+							if v15 < 2 {
 								if in.IsNull() {
 									in.Skip()
 								} else {
 									in.Delim('[')
-									v10 := 0
+									v16 := 0
 									for !in.IsDelim(']') {
-										if v10 < 2 {
-											v8[v9][v10] = float64(in.Float64())
-											v10++
+										if v16 < 2 {
+											((v14)[v15])[v16] = float64(in.Float64())
+											v16++
 										} else {
 											in.SkipRecursive()
 										}
@@ -504,7 +626,7 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer,
 									}
 									in.Delim(']')
 								}
-								v9++
+								v15++
 							} else {
 								in.SkipRecursive()
 							}
@@ -512,7 +634,7 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer,
 						}
 						in.Delim(']')
 					}
-					out.DebugSegments = append(out.DebugSegments, v8)
+					out.DebugSegments = append(out.DebugSegments, v14)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -533,9 +655,9 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer,
 					out.Events = (out.Events)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v11 VizMessageEvent
-					(v11).UnmarshalEasyJSON(in)
-					out.Events = append(out.Events, v11)
+					var v17 VizMessageEvent
+					(v17).UnmarshalEasyJSON(in)
+					out.Events = append(out.Events, v17)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -550,63 +672,64 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes3(out *jwriter.Writer, in VizMessage) {
+func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes4(out *jwriter.Writer, in VizMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
 		const prefix string = ",\"GameID\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix[1:])
 		out.String(string(in.GameID))
 	}
 	{
 		const prefix string = ",\"Objects\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		if in.Objects == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v12, v13 := range in.Objects {
-				if v12 > 0 {
+			for v18, v19 := range in.Objects {
+				if v18 > 0 {
 					out.RawByte(',')
 				}
-				(v13).MarshalEasyJSON(out)
+				(v19).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
 	{
-		const prefix string = ",\"DebugPoints\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
+		const prefix string = ",\"Obstacles\":"
+		out.RawString(prefix)
+		if in.Obstacles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
 		} else {
-			out.RawString(prefix)
+			out.RawByte('[')
+			for v20, v21 := range in.Obstacles {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				(v21).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
 		}
+	}
+	{
+		const prefix string = ",\"DebugPoints\":"
+		out.RawString(prefix)
 		if in.DebugPoints == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v14, v15 := range in.DebugPoints {
-				if v14 > 0 {
+			for v22, v23 := range in.DebugPoints {
+				if v22 > 0 {
 					out.RawByte(',')
 				}
 				out.RawByte('[')
-				for v16 := range v15 {
-					if v16 > 0 {
+				for v24 := range v23 {
+					if v24 > 0 {
 						out.RawByte(',')
 					}
-					out.Float64(float64(v15[v16]))
+					out.Float64(float64((v23)[v24]))
 				}
 				out.RawByte(']')
 			}
@@ -615,31 +738,26 @@ func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes3(out *jwriter.Write
 	}
 	{
 		const prefix string = ",\"DebugSegments\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		if in.DebugSegments == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v17, v18 := range in.DebugSegments {
-				if v17 > 0 {
+			for v25, v26 := range in.DebugSegments {
+				if v25 > 0 {
 					out.RawByte(',')
 				}
 				out.RawByte('[')
-				for v19 := range v18 {
-					if v19 > 0 {
+				for v27 := range v26 {
+					if v27 > 0 {
 						out.RawByte(',')
 					}
 					out.RawByte('[')
-					for v20 := range v18[v19] {
-						if v20 > 0 {
+					for v28 := range (v26)[v27] {
+						if v28 > 0 {
 							out.RawByte(',')
 						}
-						out.Float64(float64(v18[v19][v20]))
+						out.Float64(float64(((v26)[v27])[v28]))
 					}
 					out.RawByte(']')
 				}
@@ -650,21 +768,16 @@ func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes3(out *jwriter.Write
 	}
 	{
 		const prefix string = ",\"Events\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		if in.Events == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v21, v22 := range in.Events {
-				if v21 > 0 {
+			for v29, v30 := range in.Events {
+				if v29 > 0 {
 					out.RawByte(',')
 				}
-				(v22).MarshalEasyJSON(out)
+				(v30).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -675,27 +788,27 @@ func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes3(out *jwriter.Write
 // MarshalJSON supports json.Marshaler interface
 func (v VizMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes3(&w, v)
+	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v VizMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes3(w, v)
+	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *VizMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(&r, v)
+	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *VizMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes3(l, v)
+	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes4(l, v)
 }
-func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes4(in *jlexer.Lexer, out *PlayerInfo) {
+func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes5(in *jlexer.Lexer, out *PlayerInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -732,48 +845,28 @@ func easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes4(in *jlexer.Lexer,
 		in.Consumed()
 	}
 }
-func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes4(out *jwriter.Writer, in PlayerInfo) {
+func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes5(out *jwriter.Writer, in PlayerInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
 		const prefix string = ",\"IsAlive\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix[1:])
 		out.Bool(bool(in.IsAlive))
 	}
 	{
 		const prefix string = ",\"PlayerId\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.String(string(in.PlayerId))
 	}
 	{
 		const prefix string = ",\"PlayerName\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.String(string(in.PlayerName))
 	}
 	{
 		const prefix string = ",\"Score\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		(in.Score).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
@@ -782,23 +875,23 @@ func easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes4(out *jwriter.Write
 // MarshalJSON supports json.Marshaler interface
 func (v PlayerInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes4(&w, v)
+	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PlayerInfo) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes4(w, v)
+	easyjson54cb076dEncodeGithubComBytearenaCoreCommonTypes5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PlayerInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes4(&r, v)
+	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PlayerInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes4(l, v)
+	easyjson54cb076dDecodeGithubComBytearenaCoreCommonTypes5(l, v)
 }
@@ -0,0 +1,173 @@
+package polygon
+
+import (
+	"errors"
+
+	"github.com/bytearena/core/common/utils/trigo"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+// Splits a simple polygon (open, any winding) into convex CCW polygons of at most maxVertices points
+// Ear clipping, then Hertel-Mehlhorn: adjacent pieces are merged as long as they remain convex
+func DecomposeConvex(poly []vector.Vector2, maxVertices int) ([][]vector.Vector2, error) {
+
+	if maxVertices < 3 {
+		return nil, errors.New("Convex pieces need at least 3 vertices")
+	}
+
+	if !IsSimple(poly) {
+		return nil, errors.New("Cannot decompose a polygon that is not simple")
+	}
+
+	if IsCW(GetOpenPolygonWinding(poly)) {
+		poly = InvertWinding(poly)
+	}
+
+	triangles, err := triangulate(poly)
+	if err != nil {
+		return nil, err
+	}
+
+	pieces := mergeConvexPieces(poly, triangles, maxVertices)
+
+	res := make([][]vector.Vector2, len(pieces))
+	for i, piece := range pieces {
+		res[i] = make([]vector.Vector2, len(piece))
+		for j, index := range piece {
+			res[i][j] = poly[index]
+		}
+	}
+
+	return res, nil
+}
+
+// Ear clipping of a simple CCW polygon; triangles are CCW indexes in poly
+func triangulate(poly []vector.Vector2) ([][]int, error) {
+
+	remaining := make([]int, len(poly))
+	for i := range remaining {
+		remaining[i] = i
+	}
+
+	triangles := make([][]int, 0, len(poly)-2)
+
+	for len(remaining) > 3 {
+		clipped := false
+
+		for i := range remaining {
+			prev := remaining[(i+len(remaining)-1)%len(remaining)]
+			cur := remaining[i]
+			next := remaining[(i+1)%len(remaining)]
+
+			turn := getTurn(poly[prev], poly[cur], poly[next])
+			if turn < 0 {
+				// reflex vertex
+				continue
+			}
+
+			if turn > 0 && !isEar(poly, remaining, prev, cur, next) {
+				continue
+			}
+
+			if turn > 0 {
+				triangles = append(triangles, []int{prev, cur, next})
+			} // flat vertex: removed without a triangle
+
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			clipped = true
+			break
+		}
+
+		if !clipped {
+			return nil, errors.New("Could not triangulate polygon; is it simple?")
+		}
+	}
+
+	if getTurn(poly[remaining[0]], poly[remaining[1]], poly[remaining[2]]) > 0 {
+		triangles = append(triangles, []int{remaining[0], remaining[1], remaining[2]})
+	}
+
+	return triangles, nil
+}
+
+// No other remaining vertex lies in the triangle
+func isEar(poly []vector.Vector2, remaining []int, prev, cur, next int) bool {
+	for _, other := range remaining {
+		if other == prev || other == cur || other == next {
+			continue
+		}
+
+		if trigo.PointIsInTriangle(poly[other], poly[prev], poly[cur], poly[next]) {
+			return false
+		}
+
+		// vertices touching the diagonal would make the remaining polygon degenerate
+		if trigo.PointOnLineSegment(poly[other], poly[prev], poly[next]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mergeConvexPieces(poly []vector.Vector2, pieces [][]int, maxVertices int) [][]int {
+
+	for merged := true; merged; {
+		merged = false
+
+		for i := 0; i < len(pieces) && !merged; i++ {
+			for j := i + 1; j < len(pieces) && !merged; j++ {
+				union, ok := mergePieces(pieces[i], pieces[j])
+				if !ok || len(union) > maxVertices || !isConvexPiece(poly, union) {
+					continue
+				}
+
+				pieces[i] = union
+				pieces = append(pieces[:j], pieces[j+1:]...)
+				merged = true
+			}
+		}
+	}
+
+	return pieces
+}
+
+// Union of two CCW pieces sharing an edge (a -> b in the first one, b -> a in the second one)
+func mergePieces(first, second []int) ([]int, bool) {
+	for k := range first {
+		a := first[k]
+		b := first[(k+1)%len(first)]
+
+		for m := range second {
+			if second[m] != b || second[(m+1)%len(second)] != a {
+				continue
+			}
+
+			union := make([]int, 0, len(first)+len(second)-2)
+
+			// b ... a along the first piece
+			for n := 0; n < len(first); n++ {
+				union = append(union, first[(k+1+n)%len(first)])
+			}
+
+			// then the second piece, from after a to before b
+			for n := 0; n < len(second)-2; n++ {
+				union = append(union, second[(m+2+n)%len(second)])
+			}
+
+			return union, true
+		}
+	}
+
+	return nil, false
+}
+
+func isConvexPiece(poly []vector.Vector2, piece []int) bool {
+	for i := range piece {
+		if getTurn(poly[piece[(i+len(piece)-1)%len(piece)]], poly[piece[i]], poly[piece[(i+1)%len(piece)]]) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,125 @@
+package polygon
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+func makePolygon(coords ...float64) []vector.Vector2 {
+	poly := make([]vector.Vector2, 0, len(coords)/2)
+	for i := 0; i+1 < len(coords); i += 2 {
+		poly = append(poly, vector.MakeVector2(coords[i], coords[i+1]))
+	}
+
+	return poly
+}
+
+// Open star-shaped polygon: concave, but simple
+func makeRandomStar(rnd *rand.Rand) []vector.Vector2 {
+	nbPoints := 4 + rnd.Intn(20)
+	poly := make([]vector.Vector2, nbPoints)
+
+	for i := range poly {
+		angle := 2 * math.Pi * (float64(i) + 0.5*rnd.Float64()) / float64(nbPoints)
+		radius := 1 + rnd.Float64()*10
+		poly[i] = vector.MakeVector2(radius*math.Cos(angle), radius*math.Sin(angle))
+	}
+
+	return poly
+}
+
+func checkDecomposition(t *testing.T, poly []vector.Vector2, maxVertices int) {
+	t.Helper()
+
+	pieces, err := DecomposeConvex(poly, maxVertices)
+	if err != nil {
+		t.Fatalf("%v: %s", poly, err.Error())
+	}
+
+	area := 0.0
+	for _, piece := range pieces {
+		if len(piece) > maxVertices {
+			t.Fatalf("%v: piece %v has more than %d vertices", poly, piece, maxVertices)
+		}
+
+		if !IsConvex(piece) {
+			t.Fatalf("%v: piece %v is not convex", poly, piece)
+		}
+
+		if !IsCCW(GetOpenPolygonWinding(piece)) {
+			t.Fatalf("%v: piece %v is not CCW", poly, piece)
+		}
+
+		area += GetSignedArea(piece)
+	}
+
+	if expected := math.Abs(GetSignedArea(poly)); math.Abs(area-expected) > 0.000001 {
+		t.Fatalf("%v: pieces cover %f, expected %f", poly, area, expected)
+	}
+}
+
+func TestDecomposeConvex(t *testing.T) {
+	square := makePolygon(0, 0, 1, 0, 1, 1, 0, 1)
+
+	pieces, err := DecomposeConvex(square, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pieces) != 1 {
+		t.Errorf("convex polygon split in %d pieces, expected 1", len(pieces))
+	}
+
+	// L shape, clockwise
+	checkDecomposition(t, makePolygon(0, 0, 0, 2, 1, 2, 1, 1, 2, 1, 2, 0), 8)
+
+	// U shape, with a flat vertex
+	checkDecomposition(t, makePolygon(0, 0, 3, 0, 3, 3, 2, 3, 2, 1, 1, 1, 1, 3, 0, 3, 0, 1.5), 8)
+
+	// convex, but too many vertices for a single piece
+	circle := make([]vector.Vector2, 20)
+	for i := range circle {
+		angle := 2 * math.Pi * float64(i) / float64(len(circle))
+		circle[i] = vector.MakeVector2(math.Cos(angle), math.Sin(angle))
+	}
+	checkDecomposition(t, circle, 8)
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		poly := makeRandomStar(rnd)
+		if i%2 == 0 {
+			poly = InvertWinding(poly)
+		}
+
+		checkDecomposition(t, poly, 3+rnd.Intn(6))
+	}
+}
+
+func TestDecomposeConvexInvalid(t *testing.T) {
+	bowtie := makePolygon(0, 0, 1, 1, 1, 0, 0, 1)
+	if _, err := DecomposeConvex(bowtie, 8); err == nil {
+		t.Error("self-intersecting polygon decomposed")
+	}
+
+	if _, err := DecomposeConvex(makePolygon(0, 0, 1, 1), 8); err == nil {
+		t.Error("segment decomposed")
+	}
+}
+
+func TestFindSelfIntersections(t *testing.T) {
+	if intersections := FindSelfIntersections(makePolygon(0, 0, 1, 1, 1, 0, 0, 1)); len(intersections) != 1 {
+		t.Errorf("bowtie: %v, expected one intersection", intersections)
+	}
+
+	// spike going back on the previous edge
+	if intersections := FindSelfIntersections(makePolygon(0, 0, 2, 0, 1, 0, 1, 1)); len(intersections) == 0 {
+		t.Error("spike not detected")
+	}
+
+	if intersections := FindSelfIntersections(makePolygon(0, 0, 0, 2, 1, 2, 1, 1, 2, 1, 2, 0)); len(intersections) != 0 {
+		t.Errorf("L shape: %v, expected none", intersections)
+	}
+}
@@ -0,0 +1,99 @@
+package polygon
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+// Closed polygon (last point is first point), points ordered by angle around the origin
+func makeRandomPolygon(rnd *rand.Rand, clockwise bool) []vector.Vector2 {
+	nbPoints := 3 + rnd.Intn(10)
+	poly := make([]vector.Vector2, 0, nbPoints+1)
+
+	for i := 0; i < nbPoints; i++ {
+		angle := 2 * math.Pi * (float64(i) + 0.5*rnd.Float64()) / float64(nbPoints)
+		if clockwise {
+			angle = -angle
+		}
+
+		radius := 1 + rnd.Float64()*10
+		poly = append(poly, vector.MakeVector2(radius*math.Cos(angle), radius*math.Sin(angle)))
+	}
+
+	return append(poly, poly[0])
+}
+
+func TestGetPolygonWindingForCartesianSystem(t *testing.T) {
+	ccw := []vector.Vector2{
+		vector.MakeVector2(0, 0),
+		vector.MakeVector2(1, 0),
+		vector.MakeVector2(1, 1),
+		vector.MakeVector2(0, 1),
+		vector.MakeVector2(0, 0),
+	}
+
+	if winding := GetPolygonWindingForCartesianSystem(ccw); !IsCCW(winding) {
+		t.Errorf("winding = %d, expected CCW", winding)
+	}
+
+	if winding := GetPolygonWindingForCartesianSystem(InvertWinding(ccw)); !IsCW(winding) {
+		t.Errorf("winding = %d, expected CW", winding)
+	}
+
+	if winding := GetPolygonWindingForCartesianSystem(ccw[:2]); winding != 0 {
+		t.Errorf("winding of a segment = %d, expected 0", winding)
+	}
+}
+
+func TestInvertWindingRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		clockwise := i%2 == 0
+		poly := makeRandomPolygon(rnd, clockwise)
+
+		winding := GetPolygonWindingForCartesianSystem(poly)
+		if IsCW(winding) != clockwise {
+			t.Fatalf("winding = %d, expected clockwise = %v for %v", winding, clockwise, poly)
+		}
+
+		inverted := InvertWinding(poly)
+		if invertedWinding := GetPolygonWindingForCartesianSystem(inverted); invertedWinding != -winding {
+			t.Fatalf("inverted winding = %d, expected %d for %v", invertedWinding, -winding, poly)
+		}
+
+		roundTrip := InvertWinding(inverted)
+		for j := range poly {
+			if !roundTrip[j].Equals(poly[j]) {
+				t.Fatalf("point %d = %v after round trip, expected %v", j, roundTrip[j], poly[j])
+			}
+		}
+	}
+}
+
+func TestEnsureWinding(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		poly := makeRandomPolygon(rnd, rnd.Intn(2) == 0)
+
+		for _, winding := range []int{CartesianSystemWinding.CW, CartesianSystemWinding.CCW} {
+			ensured, err := EnsureWinding(winding, poly)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ensuredWinding := GetPolygonWindingForCartesianSystem(ensured); ensuredWinding != winding {
+				t.Fatalf("winding = %d, expected %d for %v", ensuredWinding, winding, poly)
+			}
+		}
+	}
+
+	flat := []vector.Vector2{vector.MakeVector2(0, 0), vector.MakeVector2(1, 0), vector.MakeVector2(2, 0), vector.MakeVector2(0, 0)}
+	if _, err := EnsureWinding(CartesianSystemWinding.CW, flat); err == nil {
+		t.Error("a flat polygon has no winding")
+	}
+}
@@ -0,0 +1,151 @@
+package polygon
+
+import (
+	"math"
+
+	"github.com/bytearena/core/common/utils/trigo"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+// Polygons below are open: the last point is linked to the first one, and is not repeated
+
+// Removes the repeated points (closing point, zero length edges); returns the number of points removed
+func RemoveDegenerateEdges(poly []vector.Vector2, tolerance float64) ([]vector.Vector2, int) {
+	cleaned := make([]vector.Vector2, 0, len(poly))
+
+	for _, point := range poly {
+		if len(cleaned) > 0 && point.Sub(cleaned[len(cleaned)-1]).Mag() <= tolerance {
+			continue
+		}
+
+		cleaned = append(cleaned, point)
+	}
+
+	for len(cleaned) > 1 && cleaned[len(cleaned)-1].Sub(cleaned[0]).Mag() <= tolerance {
+		cleaned = cleaned[:len(cleaned)-1]
+	}
+
+	return cleaned, len(poly) - len(cleaned)
+}
+
+// Signed area; positive for CCW polygons in the cartesian system
+func GetSignedArea(poly []vector.Vector2) float64 {
+	sum := 0.0
+
+	prev := len(poly) - 1
+	for cur := 0; cur < len(poly); cur++ {
+		sum += poly[prev].Cross(poly[cur])
+		prev = cur
+	}
+
+	return sum / 2
+}
+
+// Winding of an open polygon, as returned by GetPolygonWindingForCartesianSystem
+func GetOpenPolygonWinding(poly []vector.Vector2) int {
+	if len(poly) == 0 {
+		return 0
+	}
+
+	closed := make([]vector.Vector2, len(poly), len(poly)+1)
+	copy(closed, poly)
+
+	return GetPolygonWindingForCartesianSystem(append(closed, poly[0]))
+}
+
+func getEdge(poly []vector.Vector2, i int) vector.Segment2 {
+	return vector.MakeSegment2(poly[i], poly[(i+1)%len(poly)])
+}
+
+// Pairs of edges crossing each other; edge i goes from poly[i] to poly[i+1]
+// Adjacent edges only count if they fold back onto each other
+func FindSelfIntersections(poly []vector.Vector2) [][2]int {
+	intersections := make([][2]int, 0)
+
+	polylen := len(poly)
+	if polylen < 3 {
+		return intersections
+	}
+
+	for i := 0; i < polylen; i++ {
+		for j := i + 1; j < polylen; j++ {
+
+			adjacent := j == i+1 || (i == 0 && j == polylen-1)
+			if adjacent {
+				if areFoldedBack(poly, i, j) {
+					intersections = append(intersections, [2]int{i, j})
+				}
+
+				continue
+			}
+
+			if _, intersects, _, _ := trigo.SegmentSegmentIntersection(getEdge(poly, i), getEdge(poly, j)); intersects {
+				intersections = append(intersections, [2]int{i, j})
+			}
+		}
+	}
+
+	return intersections
+}
+
+// Adjacent edges going back on the same line (spike)
+func areFoldedBack(poly []vector.Vector2, i, j int) bool {
+	a := getEdge(poly, i).Vector2()
+	b := getEdge(poly, j).Vector2()
+
+	return math.Abs(a.Cross(b)) <= 0.0000001*a.Mag()*b.Mag() && a.Dot(b) < 0
+}
+
+func IsSimple(poly []vector.Vector2) bool {
+	return len(poly) >= 3 && len(FindSelfIntersections(poly)) == 0
+}
+
+func IsConvex(poly []vector.Vector2) bool {
+	if len(poly) < 3 {
+		return false
+	}
+
+	sign := 0.0
+	for i := range poly {
+		cross := getTurn(poly[(i+len(poly)-1)%len(poly)], poly[i], poly[(i+1)%len(poly)])
+		if cross == 0 {
+			continue
+		}
+
+		if sign != 0 && (cross > 0) != (sign > 0) {
+			return false
+		}
+
+		sign = cross
+	}
+
+	return sign != 0
+}
+
+// > 0 when turning left (CCW) at b
+func getTurn(a, b, c vector.Vector2) float64 {
+	return b.Sub(a).Cross(c.Sub(b))
+}
+
+// inner is entirely inside outer; both are assumed simple
+func ContainsPolygon(outer []vector.Vector2, inner []vector.Vector2) bool {
+	if len(outer) < 3 || len(inner) == 0 {
+		return false
+	}
+
+	for _, point := range inner {
+		if !trigo.PointIsInPolygon(point, outer) {
+			return false
+		}
+	}
+
+	for i := range outer {
+		for j := range inner {
+			if _, intersects, _, _ := trigo.SegmentSegmentIntersection(getEdge(outer, i), getEdge(inner, j)); intersects {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,133 @@
+package space
+
+import (
+	"github.com/bytearena/box2d"
+	"github.com/go-gl/mathgl/mgl64"
+
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+// Coordinate spaces of a game:
+//
+// Map space: coordinates of the map files; the y axis points down
+// Physical space: the Box2D world; the y axis points up
+// Agent space: what the agents perceive and steer with; physical space, scaled
+// Viz space: what the viz receives; matches physical space
+//
+// Each space has its own vector type, and vectors go from a space to another only through Spaces,
+// so that mixing them up does not compile.
+// The wrapped fields are named differently on purpose: Go converts between structs with identical fields.
+
+type MapVector2 struct{ mapCoords vector.Vector2 }
+type PhysicalVector2 struct{ physicalCoords vector.Vector2 }
+type AgentVector2 struct{ agentCoords vector.Vector2 }
+type VizVector2 struct{ vizCoords vector.Vector2 }
+
+func MakeMapVector2(x float64, y float64) MapVector2 {
+	return MapVector2{vector.MakeVector2(x, y)}
+}
+
+func MakePhysicalVector2(x float64, y float64) PhysicalVector2 {
+	return PhysicalVector2{vector.MakeVector2(x, y)}
+}
+
+func MakeAgentVector2(x float64, y float64) AgentVector2 {
+	return AgentVector2{vector.MakeVector2(x, y)}
+}
+
+func MakePhysicalVector2FromVector2(v vector.Vector2) PhysicalVector2 {
+	return PhysicalVector2{v}
+}
+
+func MakeAgentVector2FromVector2(v vector.Vector2) AgentVector2 {
+	return AgentVector2{v}
+}
+
+func PhysicalFromB2Vec2(v box2d.B2Vec2) PhysicalVector2 {
+	return MakePhysicalVector2(v.X, v.Y)
+}
+
+// Raw coordinates; the space is lost
+func (v MapVector2) Vector2() vector.Vector2      { return v.mapCoords }
+func (v PhysicalVector2) Vector2() vector.Vector2 { return v.physicalCoords }
+func (v AgentVector2) Vector2() vector.Vector2    { return v.agentCoords }
+func (v VizVector2) Vector2() vector.Vector2      { return v.vizCoords }
+
+func (v PhysicalVector2) ToB2Vec2() box2d.B2Vec2 {
+	return v.physicalCoords.ToB2Vec2()
+}
+
+// Conversions between the spaces of a game
+type Spaces struct {
+	physicalToAgentScale float64
+
+	physicalToAgent mgl64.Mat4
+	agentToPhysical mgl64.Mat4
+}
+
+// Physical to agent space: scale, then rotation (in degrees, around each axis), then translation
+func NewSpaces(scale float64, translation, rotation [3]float64) *Spaces {
+
+	rotxM := mgl64.HomogRotate3DX(mgl64.DegToRad(rotation[0]))
+	rotyM := mgl64.HomogRotate3DY(mgl64.DegToRad(rotation[1]))
+	rotzM := mgl64.HomogRotate3DZ(mgl64.DegToRad(rotation[2]))
+	transM := mgl64.Translate3D(translation[0], translation[1], translation[2])
+	scaleM := mgl64.Scale3D(scale, scale, scale)
+
+	transform := mgl64.Ident4().
+		Mul4(transM).
+		Mul4(rotzM).
+		Mul4(rotyM).
+		Mul4(rotxM).
+		Mul4(scaleM)
+
+	return &Spaces{
+		physicalToAgentScale: scale,
+		physicalToAgent:      transform,
+		agentToPhysical:      transform.Inv(),
+	}
+}
+
+func (s *Spaces) MapToPhysical(v MapVector2) PhysicalVector2 {
+	return MakePhysicalVector2(v.mapCoords.GetX(), -1*v.mapCoords.GetY())
+}
+
+func (s *Spaces) MapToAgent(v MapVector2) AgentVector2 {
+	return s.PhysicalToAgent(s.MapToPhysical(v))
+}
+
+func (s *Spaces) PhysicalToAgent(v PhysicalVector2) AgentVector2 {
+	return AgentVector2{v.physicalCoords.Transform(&s.physicalToAgent)}
+}
+
+func (s *Spaces) AgentToPhysical(v AgentVector2) PhysicalVector2 {
+	return PhysicalVector2{v.agentCoords.Transform(&s.agentToPhysical)}
+}
+
+func (s *Spaces) PhysicalToViz(v PhysicalVector2) VizVector2 {
+	return VizVector2{v.physicalCoords}
+}
+
+func (s *Spaces) AgentToViz(v AgentVector2) VizVector2 {
+	return s.PhysicalToViz(s.AgentToPhysical(v))
+}
+
+// Directions and velocities are not translated
+func (s *Spaces) PhysicalToAgentDirection(v PhysicalVector2) AgentVector2 {
+	origin := s.PhysicalToAgent(MakePhysicalVector2(0, 0))
+	return AgentVector2{s.PhysicalToAgent(v).agentCoords.Sub(origin.agentCoords)}
+}
+
+func (s *Spaces) AgentToPhysicalDirection(v AgentVector2) PhysicalVector2 {
+	origin := s.AgentToPhysical(MakeAgentVector2(0, 0))
+	return PhysicalVector2{s.AgentToPhysical(v).physicalCoords.Sub(origin.physicalCoords)}
+}
+
+// Lengths only depend on the scale
+func (s *Spaces) PhysicalToAgentDistance(d float64) float64 {
+	return d * s.physicalToAgentScale
+}
+
+func (s *Spaces) AgentToPhysicalDistance(d float64) float64 {
+	return d / s.physicalToAgentScale
+}
@@ -0,0 +1,51 @@
+package space
+
+import (
+	"math"
+	"testing"
+)
+
+const testTolerance = 0.000001
+
+func TestMapToPhysical(t *testing.T) {
+	spaces := NewSpaces(100, [3]float64{0, 0, 0}, [3]float64{0, 0, 0})
+
+	physical := spaces.MapToPhysical(MakeMapVector2(3, 4))
+	if physical.Vector2() != MakePhysicalVector2(3, -4).Vector2() {
+		t.Errorf("got %v, expected the y axis flipped", physical.Vector2())
+	}
+
+	agent := spaces.MapToAgent(MakeMapVector2(3, 4))
+	if !agent.Vector2().EqualsWithPrecision(MakeAgentVector2(300, -400).Vector2(), testTolerance) {
+		t.Errorf("got %v, expected the y axis flipped, and scaled", agent.Vector2())
+	}
+
+	if viz := spaces.PhysicalToViz(physical); viz.Vector2() != physical.Vector2() {
+		t.Errorf("got %v, expected viz and physical spaces to match", viz.Vector2())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	spaces := NewSpaces(100, [3]float64{10, 0, -5}, [3]float64{0, 30, 0})
+
+	physical := MakePhysicalVector2(1.5, -2.25)
+	agent := spaces.PhysicalToAgent(physical)
+
+	if back := spaces.AgentToPhysical(agent); !back.Vector2().EqualsWithPrecision(physical.Vector2(), testTolerance) {
+		t.Errorf("got %v, expected %v", back.Vector2(), physical.Vector2())
+	}
+
+	// directions are not translated
+	direction := spaces.PhysicalToAgentDirection(physical)
+	if d := direction.Vector2().Mag(); math.Abs(d-100*physical.Vector2().Mag()) > testTolerance {
+		t.Errorf("direction of length %f, expected %f", d, 100*physical.Vector2().Mag())
+	}
+
+	if back := spaces.AgentToPhysicalDirection(direction); !back.Vector2().EqualsWithPrecision(physical.Vector2(), testTolerance) {
+		t.Errorf("got %v, expected %v", back.Vector2(), physical.Vector2())
+	}
+
+	if d := spaces.AgentToPhysicalDistance(spaces.PhysicalToAgentDistance(0.5)); d != 0.5 {
+		t.Errorf("got %f, expected 0.5", d)
+	}
+}
@@ -0,0 +1,164 @@
+package trigo
+
+import (
+	"math"
+
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+// Swept tests: a circle moves linearly from start to end during the step
+// toi is the fraction of the step at which the circle first touches the target, in [0, 1]
+// point is the contact point at that time; toi is 0 if they already overlap at start
+
+// Moving circle against a static circle
+func SweptCircleCircle(start, end vector.Vector2, radius float64, center vector.Vector2, otherRadius float64) (toi float64, point vector.Vector2, hit bool) {
+
+	d := end.Sub(start)
+	f := start.Sub(center)
+	r := radius + otherRadius
+
+	c := f.Dot(f) - r*r
+	if c <= 0 {
+		return 0, getCircleContactPoint(start, center, otherRadius), true
+	}
+
+	a := d.Dot(d)
+	if a == 0 {
+		return 0, vector.MakeNullVector2(), false
+	}
+
+	b := 2 * f.Dot(d)
+	if b >= 0 {
+		// moving away
+		return 0, vector.MakeNullVector2(), false
+	}
+
+	delta := b*b - 4*a*c
+	if delta < 0 {
+		return 0, vector.MakeNullVector2(), false
+	}
+
+	toi = (-b - math.Sqrt(delta)) / (2 * a)
+	if toi < 0 || toi > 1 {
+		return 0, vector.MakeNullVector2(), false
+	}
+
+	return toi, getCircleContactPoint(start.Add(d.Scale(toi)), center, otherRadius), true
+}
+
+// Point of the circle facing the other center
+func getCircleContactPoint(otherCenter, center vector.Vector2, radius float64) vector.Vector2 {
+	direction := otherCenter.Sub(center)
+	if direction.IsNull() {
+		return center
+	}
+
+	return center.Add(direction.SetMag(radius))
+}
+
+// Moving circle against a static segment; thin walls cannot be tunneled through
+func SweptCircleSegment(start, end vector.Vector2, radius float64, segment vector.Segment2) (toi float64, point vector.Vector2, hit bool) {
+
+	a, b := segment.Get()
+
+	if PointSegmentDistance(start, segment) <= radius {
+		return 0, getClosestPointOnSegment(start, segment), true
+	}
+
+	ab := b.Sub(a)
+	if ab.IsNull() {
+		return SweptCircleCircle(start, end, radius, a, 0)
+	}
+
+	toi = math.Inf(1)
+
+	// Side of the segment: the circle touches the line at distance radius
+	normal := ab.OrthogonalCounterClockwise().Normalize()
+	distStart := start.Sub(a).Dot(normal)
+	distEnd := end.Sub(a).Dot(normal)
+
+	side := 1.0
+	if distStart < 0 {
+		side = -1.0
+	}
+
+	if math.Abs(distStart) > radius && distStart != distEnd {
+		t := (distStart - side*radius) / (distStart - distEnd)
+		if t >= 0 && t <= 1 {
+			center := start.Add(end.Sub(start).Scale(t))
+			projection := center.Sub(a).Dot(ab) / ab.MagSq()
+			if projection >= 0 && projection <= 1 {
+				toi = t
+			}
+		}
+	}
+
+	// Ends of the segment
+	for _, tip := range []vector.Vector2{a, b} {
+		if t, _, ok := SweptCircleCircle(start, end, radius, tip, 0); ok && t < toi {
+			toi = t
+		}
+	}
+
+	if math.IsInf(toi, 1) {
+		return 0, vector.MakeNullVector2(), false
+	}
+
+	return toi, getClosestPointOnSegment(start.Add(end.Sub(start).Scale(toi)), segment), true
+}
+
+const sweptMaxIterations = 100
+const sweptTolerance = 0.000001
+
+// Moving circle against a segment whose ends move linearly, each at its own speed (a rotating bar)
+// Conservative advancement: no point of the segment moves faster than the fastest of its ends,
+// so the circle and the segment cannot meet before the distance between them is covered at that speed
+func SweptCircleMovingSegment(start, end vector.Vector2, radius float64, segmentStart, segmentEnd vector.Segment2) (toi float64, point vector.Vector2, hit bool) {
+
+	move := end.Sub(start)
+
+	a0, b0 := segmentStart.Get()
+	a1, b1 := segmentEnd.Get()
+	moveA := a1.Sub(a0)
+	moveB := b1.Sub(b0)
+
+	// fastest end, relative to the circle
+	maxSpeed := math.Max(moveA.Sub(move).Mag(), moveB.Sub(move).Mag())
+
+	for i := 0; i < sweptMaxIterations; i++ {
+		center := start.Add(move.Scale(toi))
+		segment := vector.MakeSegment2(a0.Add(moveA.Scale(toi)), b0.Add(moveB.Scale(toi)))
+
+		// still closing in after the last iteration: grazing, counted as a hit
+		gap := PointSegmentDistance(center, segment) - radius
+		if gap <= sweptTolerance || i == sweptMaxIterations-1 {
+			return toi, getClosestPointOnSegment(center, segment), true
+		}
+
+		if maxSpeed == 0 {
+			return 0, vector.MakeNullVector2(), false
+		}
+
+		toi += gap / maxSpeed
+		if toi > 1 {
+			return 0, vector.MakeNullVector2(), false
+		}
+	}
+
+	return 0, vector.MakeNullVector2(), false
+}
+
+func getClosestPointOnSegment(point vector.Vector2, segment vector.Segment2) vector.Vector2 {
+	a, b := segment.Get()
+	ab := b.Sub(a)
+
+	lengthSq := ab.MagSq()
+	if lengthSq == 0 {
+		return a
+	}
+
+	// projection of the point on the segment, clamped to its ends
+	t := math.Max(0, math.Min(1, point.Sub(a).Dot(ab)/lengthSq))
+
+	return a.Add(ab.Scale(t))
+}
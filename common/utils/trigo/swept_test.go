@@ -0,0 +1,148 @@
+package trigo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+func TestSweptCircleCircle(t *testing.T) {
+	start := vector.MakeVector2(0, 0)
+
+	// head-on: contact when the centers are 1.5 apart
+	toi, point, hit := SweptCircleCircle(start, vector.MakeVector2(10, 0), 0.5, vector.MakeVector2(5, 0), 1)
+	if !hit || math.Abs(toi-0.35) > testTolerance || !isNear(point, vector.MakeVector2(4, 0)) {
+		t.Errorf("head-on: got %v %f %v", hit, toi, point)
+	}
+
+	// passing by
+	if _, _, hit := SweptCircleCircle(start, vector.MakeVector2(10, 0), 0.5, vector.MakeVector2(5, 2), 1); hit {
+		t.Error("passing by: unexpected hit")
+	}
+
+	// too short
+	if _, _, hit := SweptCircleCircle(start, vector.MakeVector2(3, 0), 0.5, vector.MakeVector2(5, 0), 1); hit {
+		t.Error("too short: unexpected hit")
+	}
+
+	// moving away while overlapping still counts as an overlap at start
+	if toi, _, hit := SweptCircleCircle(start, vector.MakeVector2(-10, 0), 0.5, vector.MakeVector2(1, 0), 1); !hit || toi != 0 {
+		t.Errorf("overlap: got %v %f", hit, toi)
+	}
+
+	// moving away
+	if _, _, hit := SweptCircleCircle(start, vector.MakeVector2(-10, 0), 0.5, vector.MakeVector2(5, 0), 1); hit {
+		t.Error("moving away: unexpected hit")
+	}
+}
+
+func TestSweptCircleSegmentThinWall(t *testing.T) {
+	wall := makeSegment(5, -1, 5, 1)
+
+	// both ends of the move are far from the wall: a discrete test would miss it
+	toi, point, hit := SweptCircleSegment(vector.MakeVector2(0, 0), vector.MakeVector2(100, 0), 0.1, wall)
+	if !hit || math.Abs(toi-0.049) > testTolerance || !isNear(point, vector.MakeVector2(5, 0)) {
+		t.Errorf("thin wall: got %v %f %v", hit, toi, point)
+	}
+
+	// same wall, hit from the other side
+	if toi, _, hit := SweptCircleSegment(vector.MakeVector2(100, 0), vector.MakeVector2(0, 0), 0.1, wall); !hit || math.Abs(toi-0.949) > testTolerance {
+		t.Errorf("other side: got %v %f", hit, toi)
+	}
+
+	// grazing the end of the wall
+	toi, point, hit = SweptCircleSegment(vector.MakeVector2(0, 1.05), vector.MakeVector2(10, 1.05), 0.1, wall)
+	if !hit || !isNear(point, vector.MakeVector2(5, 1)) {
+		t.Errorf("end of the wall: got %v %f %v", hit, toi, point)
+	}
+
+	// passing over the wall
+	if _, _, hit := SweptCircleSegment(vector.MakeVector2(0, 1.2), vector.MakeVector2(10, 1.2), 0.1, wall); hit {
+		t.Error("over the wall: unexpected hit")
+	}
+
+	// parallel to the wall
+	if _, _, hit := SweptCircleSegment(vector.MakeVector2(4, -10), vector.MakeVector2(4, 10), 0.1, wall); hit {
+		t.Error("parallel: unexpected hit")
+	}
+
+	// touching at start
+	if toi, _, hit := SweptCircleSegment(vector.MakeVector2(4.95, 0), vector.MakeVector2(0, 0), 0.1, wall); !hit || toi != 0 {
+		t.Errorf("touching: got %v %f", hit, toi)
+	}
+
+	// zero-length wall behaves as a point
+	if toi, _, hit := SweptCircleSegment(vector.MakeVector2(0, 0), vector.MakeVector2(10, 0), 0.5, makeSegment(5, 0, 5, 0)); !hit || math.Abs(toi-0.45) > testTolerance {
+		t.Errorf("point wall: got %v %f", hit, toi)
+	}
+}
+
+// The swept test never reports a later impact than sampling the move finely would
+func TestSweptCircleSegmentSampling(t *testing.T) {
+	segment := makeSegment(-1, 2, 3, 4)
+	radius := 0.3
+
+	for i := 0; i < 100; i++ {
+		angle := 2 * math.Pi * float64(i) / 100
+		start := vector.MakeVector2(1, 3).Add(vector.MakeVector2(math.Cos(angle), math.Sin(angle)).Scale(5))
+		end := start.Add(vector.MakeVector2(1, 3).Sub(start).Scale(2))
+
+		toi, _, hit := SweptCircleSegment(start, end, radius, segment)
+		if !hit {
+			t.Fatalf("%v -> %v: no hit", start, end)
+		}
+
+		for step := 0; step <= 1000; step++ {
+			s := float64(step) / 1000
+			if PointSegmentDistance(start.Add(end.Sub(start).Scale(s)), segment) < radius-testTolerance {
+				if s < toi {
+					t.Fatalf("%v -> %v: overlap at %f, before toi %f", start, end, s, toi)
+				}
+				break
+			}
+		}
+
+		center := start.Add(end.Sub(start).Scale(toi))
+		if d := PointSegmentDistance(center, segment); math.Abs(d-radius) > testTolerance {
+			t.Fatalf("%v -> %v: distance %f at toi, expected %f", start, end, d, radius)
+		}
+	}
+}
+
+func TestSweptCircleMovingSegment(t *testing.T) {
+	// bar of length 10 pivoting around the origin, a quarter turn per step: from along x to along y
+	// its tip sweeps an arc; the ends move linearly from one position to the other
+	barStart := makeSegment(0, 0, 10, 0)
+	barEnd := makeSegment(0, 0, 0, 10)
+
+	// static circle on the way of the tip, at both ends of the step clear of the bar
+	center := vector.MakeVector2(5, 5)
+	toi, point, hit := SweptCircleMovingSegment(center, center, 0.5, barStart, barEnd)
+	if !hit || toi <= 0 || toi >= 1 {
+		t.Fatalf("static circle: got %v %f", hit, toi)
+	}
+
+	// the bar at toi touches the circle
+	bar := vector.MakeSegment2(vector.MakeVector2(0, 0), vector.MakeVector2(10-10*toi, 10*toi))
+	if d := PointSegmentDistance(center, bar); math.Abs(d-0.5) > 0.0001 {
+		t.Errorf("static circle: %f from the bar at toi, expected 0.5", d)
+	}
+
+	if d := point.Sub(center).Mag(); math.Abs(d-0.5) > 0.0001 {
+		t.Errorf("static circle: contact point %v is %f from the center, expected 0.5", point, d)
+	}
+
+	// out of reach of the bar
+	if _, _, hit := SweptCircleMovingSegment(vector.MakeVector2(20, 20), vector.MakeVector2(20, 20), 0.5, barStart, barEnd); hit {
+		t.Error("out of reach: unexpected hit")
+	}
+
+	// a translating segment gives the same result as the rigid swept test
+	wall := makeSegment(50, -10, 50, 10)
+	movedWall := makeSegment(40, -10, 40, 10)
+	expected, _, _ := SweptCircleSegment(vector.MakeVector2(0, 0), vector.MakeVector2(110, 0), 1, wall)
+	if toi, _, hit := SweptCircleMovingSegment(vector.MakeVector2(0, 0), vector.MakeVector2(100, 0), 1, wall, movedWall); !hit || math.Abs(toi-expected) > 0.0001 {
+		t.Errorf("translating wall: got %v %f, expected %f", hit, toi, expected)
+	}
+}
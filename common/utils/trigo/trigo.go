@@ -18,6 +18,21 @@ func IntersectionWithLineSegment(p vector.Vector2, p2 vector.Vector2, q vector.V
 
 	r := p2.Sub(p)
 	s := q2.Sub(q)
+
+	// Zero length segments are points: they intersect the other segment only if they lie on it
+	if r.IsNull() || s.IsNull() {
+		point, a, b := p, q, q2
+		if !r.IsNull() {
+			point, a, b = q, p, p2
+		}
+
+		if PointOnLineSegment(point, a, b) {
+			return point, true, false, false
+		}
+
+		return vector.MakeNullVector2(), false, false, false
+	}
+
 	rxs := r.Cross(s)
 	qpxr := q.Sub(p).Cross(r)
 
@@ -200,6 +215,11 @@ func CircleCircleIntersectionPoints(center0 vector.Vector2, radius0 float64, cen
 		return []vector.Vector2{}, false, true
 	}
 
+	if d == 0 {
+		/* same circle: no finite set of intersection points */
+		return []vector.Vector2{}, false, false
+	}
+
 	/* 'point 2' is the point where the line through the circle
 	 * intersection points crosses the line between the circle
 	 * centers.
@@ -215,7 +235,13 @@ func CircleCircleIntersectionPoints(center0 vector.Vector2, radius0 float64, cen
 	/* Determine the distance from point 2 to either of the
 	 * intersection points.
 	 */
-	h := math.Sqrt(math.Pow(radius0, 2) - math.Pow(a, 2))
+	hSq := math.Pow(radius0, 2) - math.Pow(a, 2)
+	if hSq < 0 {
+		/* tangent circles; rounding may give a slightly negative value */
+		hSq = 0
+	}
+
+	h := math.Sqrt(hSq)
 
 	/* Now determine the offsets of the intersection points from
 	 * point 2.
@@ -292,6 +318,30 @@ func PointIsInCircle(point vector.Vector2, center vector.Vector2, radius float64
 	return squareDist <= math.Pow(radius, 2)
 }
 
+// Even-odd rule; works for concave polygons
+func PointIsInPolygon(point vector.Vector2, polygon []vector.Vector2) bool {
+	px, py := point.Get()
+	inside := false
+
+	prev := len(polygon) - 1
+	for cur := 0; cur < len(polygon); cur++ {
+		ax, ay := polygon[cur].Get()
+		bx, by := polygon[prev].Get()
+
+		if (ay > py) != (by > py) && px < (bx-ax)*(py-ay)/(by-ay)+ax {
+			inside = !inside
+		}
+
+		prev = cur
+	}
+
+	return inside
+}
+
+func PointSegmentDistance(point vector.Vector2, segment vector.Segment2) float64 {
+	return point.Sub(getClosestPointOnSegment(point, segment)).Mag()
+}
+
 func ComputeCenterOfMass(points []vector.Vector2) (vector.Vector2, error) {
 	if len(points) == 0 {
 		return vector.MakeNullVector2(), errors.New("Cannot compute center of mass on empty list")
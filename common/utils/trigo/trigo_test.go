@@ -0,0 +1,344 @@
+package trigo
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+const testTolerance = 0.000001
+
+// Coordinates are kept in a range where float rounding stays far below the tolerance
+var quickConfig = &quick.Config{
+	MaxCount: 2000,
+	Rand:     rand.New(rand.NewSource(1)),
+	Values: func(values []reflect.Value, rnd *rand.Rand) {
+		for i := range values {
+			values[i] = reflect.ValueOf(rnd.Float64()*20 - 10)
+		}
+	},
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+func isFiniteVector(v vector.Vector2) bool {
+	return isFinite(v.GetX()) && isFinite(v.GetY())
+}
+
+func isNear(a, b vector.Vector2) bool {
+	return a.Sub(b).Mag() < testTolerance*10
+}
+
+func makeSegment(ax, ay, bx, by float64) vector.Segment2 {
+	return vector.MakeSegment2(vector.MakeVector2(ax, ay), vector.MakeVector2(bx, by))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Segment / segment intersection
+///////////////////////////////////////////////////////////////////////////////
+
+func TestSegmentSegmentIntersection(t *testing.T) {
+	cases := []struct {
+		name       string
+		p, q       vector.Segment2
+		intersects bool
+		colinear   bool
+		point      vector.Vector2 // checked only for intersecting, non colinear segments
+	}{
+		{"cross", makeSegment(-1, -1, 1, 1), makeSegment(-1, 1, 1, -1), true, false, vector.MakeVector2(0, 0)},
+		{"T junction", makeSegment(0, 0, 2, 0), makeSegment(1, 0, 1, 5), true, false, vector.MakeVector2(1, 0)},
+		{"shared end", makeSegment(0, 0, 1, 0), makeSegment(1, 0, 1, 1), true, false, vector.MakeVector2(1, 0)},
+		{"disjoint", makeSegment(0, 0, 1, 0), makeSegment(2, 1, 3, 5), false, false, vector.MakeNullVector2()},
+		{"parallel", makeSegment(0, 0, 1, 0), makeSegment(0, 1, 1, 1), false, false, vector.MakeNullVector2()},
+		{"colinear overlapping", makeSegment(0, 0, 2, 0), makeSegment(1, 0, 3, 0), true, true, vector.MakeNullVector2()},
+		{"colinear disjoint", makeSegment(0, 0, 1, 0), makeSegment(2, 0, 3, 0), false, true, vector.MakeNullVector2()},
+		{"point on segment", makeSegment(1, 0, 1, 0), makeSegment(0, 0, 2, 0), true, false, vector.MakeVector2(1, 0)},
+		{"point off segment", makeSegment(1, 1, 1, 1), makeSegment(0, 0, 2, 0), false, false, vector.MakeNullVector2()},
+	}
+
+	for _, c := range cases {
+		point, intersects, colinear, _ := SegmentSegmentIntersection(c.p, c.q)
+
+		if intersects != c.intersects {
+			t.Errorf("%s: intersects = %v, expected %v", c.name, intersects, c.intersects)
+		}
+
+		if colinear != c.colinear {
+			t.Errorf("%s: colinear = %v, expected %v", c.name, colinear, c.colinear)
+		}
+
+		if intersects && !colinear && !isNear(point, c.point) {
+			t.Errorf("%s: intersection = %v, expected %v", c.name, point, c.point)
+		}
+	}
+}
+
+// The intersection does not depend on the order of the segments, nor on the order of their points,
+// and lies on both segments
+func TestSegmentSegmentIntersectionSymmetry(t *testing.T) {
+	property := func(ax, ay, bx, by, cx, cy, dx, dy float64) bool {
+		p := makeSegment(ax, ay, bx, by)
+		q := makeSegment(cx, cy, dx, dy)
+
+		point, intersects, colinear, _ := SegmentSegmentIntersection(p, q)
+
+		for _, swapped := range [][2]vector.Segment2{
+			{q, p},
+			{makeSegment(bx, by, ax, ay), q},
+			{p, makeSegment(dx, dy, cx, cy)},
+		} {
+			otherPoint, otherIntersects, otherColinear, _ := SegmentSegmentIntersection(swapped[0], swapped[1])
+			if otherIntersects != intersects || otherColinear != colinear {
+				return false
+			}
+
+			if intersects && !colinear && !isNear(point, otherPoint) {
+				return false
+			}
+		}
+
+		if intersects && !colinear {
+			a, b := p.Get()
+			c, d := q.Get()
+			return PointOnLineSegment(point, a, b) && PointOnLineSegment(point, c, d)
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzSegmentSegmentIntersection(f *testing.F) {
+	f.Add(-1.0, -1.0, 1.0, 1.0, -1.0, 1.0, 1.0, -1.0) // cross
+	f.Add(0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 1.0, 1.0)     // zero length
+	f.Add(0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0)     // both zero length
+	f.Add(0.0, -62.0, 2.0, 0.0, 1.0, 0.0, 1.0, 0.0)   // zero length, off the other segment
+	f.Add(0.0, 0.0, 2.0, 0.0, 1.0, 0.0, 3.0, 0.0)     // colinear
+	f.Add(0.0, 0.0, 1.0, 0.0, 0.0, 1.0, 1.0, 1.0)     // parallel
+
+	f.Fuzz(func(t *testing.T, ax, ay, bx, by, cx, cy, dx, dy float64) {
+		for _, coord := range []float64{ax, ay, bx, by, cx, cy, dx, dy} {
+			if !isFinite(coord) || math.Abs(coord) > 1e6 {
+				t.Skip()
+			}
+		}
+
+		p := makeSegment(ax, ay, bx, by)
+		q := makeSegment(cx, cy, dx, dy)
+
+		point, intersects, colinear, _ := SegmentSegmentIntersection(p, q)
+		if !isFiniteVector(point) {
+			t.Fatalf("non finite intersection %v for %v and %v", point, p, q)
+		}
+
+		otherPoint, otherIntersects, otherColinear, _ := SegmentSegmentIntersection(q, p)
+		if !isFiniteVector(otherPoint) || otherIntersects != intersects || otherColinear != colinear {
+			t.Fatalf("asymmetric intersection for %v and %v", p, q)
+		}
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Point in triangle
+///////////////////////////////////////////////////////////////////////////////
+
+// Strictly inside, whatever the order of the vertices; consistent with PointIsInPolygon
+func TestPointIsInTriangleConsistency(t *testing.T) {
+	property := func(px, py, ax, ay, bx, by, cx, cy float64) bool {
+		point := vector.MakeVector2(px, py)
+		a := vector.MakeVector2(ax, ay)
+		b := vector.MakeVector2(bx, by)
+		c := vector.MakeVector2(cx, cy)
+
+		area := b.Sub(a).Cross(c.Sub(a)) / 2
+		if math.Abs(area) < 0.01 {
+			// degenerate triangle
+			return true
+		}
+
+		inside := PointIsInTriangle(point, a, b, c)
+
+		for _, permutation := range [][3]vector.Vector2{{a, c, b}, {b, a, c}, {b, c, a}, {c, a, b}, {c, b, a}} {
+			if PointIsInTriangle(point, permutation[0], permutation[1], permutation[2]) != inside {
+				return false
+			}
+		}
+
+		centroid := a.Add(b).Add(c).Scale(1.0 / 3.0)
+		if !PointIsInTriangle(centroid, a, b, c) {
+			return false
+		}
+
+		// on the edges, both tests are allowed to disagree
+		segments := []vector.Segment2{vector.MakeSegment2(a, b), vector.MakeSegment2(b, c), vector.MakeSegment2(c, a)}
+		for _, segment := range segments {
+			if PointSegmentDistance(point, segment) < testTolerance {
+				return true
+			}
+		}
+
+		return inside == PointIsInPolygon(point, []vector.Vector2{a, b, c})
+	}
+
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPointIsInTriangle(t *testing.T) {
+	a := vector.MakeVector2(0, 0)
+	b := vector.MakeVector2(4, 0)
+	c := vector.MakeVector2(0, 4)
+
+	if !PointIsInTriangle(vector.MakeVector2(1, 1), a, b, c) {
+		t.Error("(1, 1) should be inside")
+	}
+
+	if PointIsInTriangle(vector.MakeVector2(3, 3), a, b, c) {
+		t.Error("(3, 3) should be outside")
+	}
+
+	if PointIsInTriangle(vector.MakeVector2(-1, 1), a, b, c) {
+		t.Error("(-1, 1) should be outside")
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Circle / circle intersection
+///////////////////////////////////////////////////////////////////////////////
+
+func TestCircleCircleIntersectionPoints(t *testing.T) {
+	cases := []struct {
+		name                string
+		center0             vector.Vector2
+		radius0             float64
+		center1             vector.Vector2
+		radius1             float64
+		nbPoints            int
+		firstContainsSecond bool
+		secondContainsFirst bool
+	}{
+		{"secant", vector.MakeVector2(0, 0), 2, vector.MakeVector2(2, 0), 2, 2, false, false},
+		{"tangent", vector.MakeVector2(0, 0), 1, vector.MakeVector2(2, 0), 1, 2, false, false},
+		{"apart", vector.MakeVector2(0, 0), 1, vector.MakeVector2(5, 0), 1, 0, false, false},
+		{"first contains second", vector.MakeVector2(0, 0), 5, vector.MakeVector2(1, 0), 1, 0, true, false},
+		{"second contains first", vector.MakeVector2(0, 0), 1, vector.MakeVector2(1, 0), 5, 0, false, true},
+		{"concentric", vector.MakeVector2(1, 1), 1, vector.MakeVector2(1, 1), 2, 0, false, true},
+		{"same circle", vector.MakeVector2(1, 1), 2, vector.MakeVector2(1, 1), 2, 0, false, false},
+	}
+
+	for _, c := range cases {
+		points, firstContainsSecond, secondContainsFirst := CircleCircleIntersectionPoints(c.center0, c.radius0, c.center1, c.radius1)
+
+		if len(points) != c.nbPoints {
+			t.Errorf("%s: %d points, expected %d", c.name, len(points), c.nbPoints)
+		}
+
+		if firstContainsSecond != c.firstContainsSecond || secondContainsFirst != c.secondContainsFirst {
+			t.Errorf("%s: containment = (%v, %v), expected (%v, %v)", c.name, firstContainsSecond, secondContainsFirst, c.firstContainsSecond, c.secondContainsFirst)
+		}
+
+		for _, point := range points {
+			if !isFiniteVector(point) {
+				t.Errorf("%s: non finite point %v", c.name, point)
+			}
+		}
+	}
+}
+
+// The intersection points lie on both circles
+func TestCircleCircleIntersectionPointsOnCircles(t *testing.T) {
+	property := func(x0, y0, r0, x1, y1, r1 float64) bool {
+		center0 := vector.MakeVector2(x0, y0)
+		center1 := vector.MakeVector2(x1, y1)
+		radius0 := math.Abs(r0)
+		radius1 := math.Abs(r1)
+
+		points, _, _ := CircleCircleIntersectionPoints(center0, radius0, center1, radius1)
+
+		for _, point := range points {
+			if math.Abs(point.Sub(center0).Mag()-radius0) > 0.001 || math.Abs(point.Sub(center1).Mag()-radius1) > 0.001 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzCircleCircleIntersectionPoints(f *testing.F) {
+	f.Add(0.0, 0.0, 2.0, 2.0, 0.0, 2.0) // secant
+	f.Add(0.0, 0.0, 1.0, 2.0, 0.0, 1.0) // tangent
+	f.Add(0.0, 0.0, 1.0, 0.0, 0.0, 1.0) // same circle
+	f.Add(0.0, 0.0, 0.0, 0.0, 0.0, 0.0) // points
+	f.Add(0.0, 0.0, 5.0, 1.0, 0.0, 1.0) // contained
+
+	f.Fuzz(func(t *testing.T, x0, y0, r0, x1, y1, r1 float64) {
+		for _, value := range []float64{x0, y0, r0, x1, y1, r1} {
+			if !isFinite(value) || math.Abs(value) > 1e6 {
+				t.Skip()
+			}
+		}
+
+		if r0 < 0 || r1 < 0 {
+			t.Skip()
+		}
+
+		points, firstContainsSecond, secondContainsFirst := CircleCircleIntersectionPoints(vector.MakeVector2(x0, y0), r0, vector.MakeVector2(x1, y1), r1)
+
+		if firstContainsSecond && secondContainsFirst {
+			t.Fatal("circles cannot contain each other")
+		}
+
+		if (firstContainsSecond || secondContainsFirst) && len(points) > 0 {
+			t.Fatal("contained circles do not intersect")
+		}
+
+		for _, point := range points {
+			if !isFiniteVector(point) {
+				t.Fatalf("non finite point %v", point)
+			}
+		}
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Point / segment distance
+///////////////////////////////////////////////////////////////////////////////
+
+func TestPointSegmentDistance(t *testing.T) {
+	segment := makeSegment(0, 0, 4, 0)
+
+	cases := []struct {
+		point    vector.Vector2
+		distance float64
+	}{
+		{vector.MakeVector2(2, 3), 3},  // above the segment
+		{vector.MakeVector2(-3, 4), 5}, // beyond the first end
+		{vector.MakeVector2(7, -4), 5}, // beyond the second end
+		{vector.MakeVector2(1, 0), 0},  // on the segment
+	}
+
+	for _, c := range cases {
+		if distance := PointSegmentDistance(c.point, segment); math.Abs(distance-c.distance) > testTolerance {
+			t.Errorf("distance of %v = %f, expected %f", c.point, distance, c.distance)
+		}
+	}
+
+	if distance := PointSegmentDistance(vector.MakeVector2(3, 4), makeSegment(0, 0, 0, 0)); math.Abs(distance-5) > testTolerance {
+		t.Errorf("distance to a zero length segment = %f, expected 5", distance)
+	}
+}
@@ -0,0 +1,222 @@
+package vector
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func check(t *testing.T, ok bool, testname string) {
+	t.Helper()
+
+	if !ok {
+		t.Error("FAILED: " + testname)
+	}
+}
+
+func TestSegment2(t *testing.T) {
+	va := MakeVector2(-1.5, 3.5)
+	vb := MakeVector2(-3, 2.5)
+
+	var sExpected Segment2
+	var vExpected Vector2
+	s := MakeSegment2(va, vb)
+
+	// Clone
+	sclone := s.Clone()
+
+	check(t, s[0].Equals(sclone[0]), "Cloned [0]")
+	check(t, s[1].Equals(sclone[1]), "Cloned [1]")
+
+	// Equals
+	check(t, sclone.Equals(s), "Equals")
+
+	// JSON
+	json, _ := json.Marshal(s)
+	check(t, string(json) == "[[-1.5,3.5],[-3,2.5]]", "JSON")
+
+	// Add
+	vadd := MakeVector2(-1, 10)
+	sExpected = MakeSegment2(
+		va.Add(vadd),
+		vb.Add(vadd),
+	)
+	check(t, s.Add(vadd).Equals(sExpected), "Add")
+
+	// AddScalar
+	sExpected = MakeSegment2(
+		va.AddScalar(8.444),
+		vb.AddScalar(8.444),
+	)
+	check(t, s.AddScalar(8.444).Equals(sExpected), "AddScalar")
+
+	// Sub
+	sExpected = MakeSegment2(
+		va.Sub(vadd),
+		vb.Sub(vadd),
+	)
+	check(t, s.Sub(vadd).Equals(sExpected), "Sub")
+
+	// SubScalar
+	sExpected = MakeSegment2(
+		va.SubScalar(8.444),
+		vb.SubScalar(8.444),
+	)
+	check(t, s.SubScalar(8.444).Equals(sExpected), "SubScalar")
+
+	// Vector2
+	vExpected = vb.Sub(va)
+	check(t, s.Vector2().Equals(vExpected), "Vector2")
+
+	// Center
+	vExpected = MakeVector2(-2.25, 3)
+	check(t, s.Center().Equals(vExpected), "Center")
+
+	// Translate
+	vtranslate := MakeVector2(-123, 10)
+	sExpected = MakeSegment2(
+		MakeVector2(-124.5, 13.5),
+		MakeVector2(-126, 12.5),
+	)
+	check(t, s.Translate(vtranslate).Equals(sExpected), "Translate")
+
+	// ScaleFromA
+	sExpected = MakeSegment2(
+		va,
+		MakeVector2(-4.5, 1.5),
+	)
+	check(t, s.ScaleFromA(2).Equals(sExpected), "ScaleFromA")
+
+	// ScaleFromB
+	sExpected = MakeSegment2(
+		MakeVector2(0, 4.5),
+		vb,
+	)
+	check(t, s.ScaleFromB(2).Equals(sExpected), "ScaleFromB")
+
+	// ScaleFromCenter
+	sExpected = MakeSegment2(
+		MakeVector2(-0.75, 4),
+		MakeVector2(-3.75, 2),
+	)
+	check(t, s.ScaleFromCenter(2).Equals(sExpected), "ScaleFromCenter")
+
+	// LengthSq
+	fexpected := 1.8027756377319946 * 1.8027756377319946
+	check(t, isZero(s.LengthSq()-fexpected), "LengthSq")
+
+	// LengthSq
+	fexpected = 1.8027756377319946
+	check(t, isZero(s.Length()-fexpected), "Length")
+
+	// NormalizeFromA
+	normalized := s.Vector2().Normalize()
+	sExpected = s
+	sExpected[1] = sExpected[0].Add(normalized)
+	check(t, isZero(s.NormalizeFromA().Length()-1.0), "NormalizeFromA:Length")
+	check(t, s.NormalizeFromA().Equals(sExpected), "NormalizeFromA")
+
+	// NormalizeFromB
+	sExpected = s
+	sExpected[0] = sExpected[1].Sub(normalized)
+	check(t, isZero(s.NormalizeFromB().Length()-1.0), "NormalizeFromB:Length")
+	check(t, s.NormalizeFromB().Equals(sExpected), "NormalizeFromB")
+
+	// NormalizeFromCenter
+	sExpected = MakeSegment2(
+		MakeVector2(-2.666025, 2.72265),
+		MakeVector2(-1.833975, 3.27735),
+	)
+	check(t, isZero(s.NormalizeFromCenter().Length()-1.0), "NormalizeFromCenter:Length")
+	check(t, s.NormalizeFromCenter().Equals(sExpected), "NormalizeFromCenter")
+
+	// SetLengthFromA
+	sExpected = MakeSegment2(
+		MakeVector2(-1.5, 3.5),
+		MakeVector2(-1.5+-0.416025, 3.5+-0.27735),
+	)
+	check(t, isZero(s.SetLengthFromA(0.5).Length()-0.5), "SetLengthFromA:Length")
+	check(t, s.SetLengthFromA(0.5).Equals(sExpected), "SetLengthFromA")
+
+	// SetLengthFromB
+	sExpected = MakeSegment2(
+		MakeVector2(-3.0 - -0.416025, 2.5 - -0.27735),
+		MakeVector2(-3.0, 2.5),
+	)
+	check(t, isZero(s.SetLengthFromB(0.5).Length()-0.5), "SetLengthFromB:Length")
+	check(t, s.SetLengthFromB(0.5).Equals(sExpected), "SetLengthFromB")
+
+	// SetLengthFromCenter
+	sExpected = s.NormalizeFromCenter()
+	check(t, isZero(s.SetLengthFromCenter(1).Length()-1.0), "SetLengthFromCenter:Length")
+	check(t, s.SetLengthFromCenter(1).Equals(sExpected), "SetLengthFromCenter")
+
+	// OrthogonalToAClockwise
+	sExpected = MakeSegment2(
+		MakeVector2(-1.5000, 3.5000),
+		MakeVector2(-2.5000, 5.0000),
+	)
+	check(t, s.OrthogonalToAClockwise().Equals(sExpected), "OrthogonalToAClockwise")
+
+	// OrthogonalToACounterClockwise
+	sExpected = MakeSegment2(
+		MakeVector2(-1.5000, 3.5000),
+		MakeVector2(-0.5000, 2.0000),
+	)
+	check(t, s.OrthogonalToACounterClockwise().Equals(sExpected), "OrthogonalToACounterClockwise")
+
+	// OrthogonalToACentered
+	sExpected = MakeSegment2(
+		MakeVector2(-1.0000, 2.7500),
+		MakeVector2(-2.0000, 4.2500),
+	)
+	check(t, s.OrthogonalToACentered().Equals(sExpected), "OrthogonalToACentered")
+
+	// OrthogonalToBClockwise
+	sExpected = MakeSegment2(
+		MakeVector2(-3, 2.5),
+		MakeVector2(-4, 4),
+	)
+	check(t, s.OrthogonalToBClockwise().Equals(sExpected), "OrthogonalToBClockwise")
+
+	// OrthogonalToBCounterClockwise
+	sExpected = MakeSegment2(
+		MakeVector2(-3, 2.5),
+		MakeVector2(-2, 1),
+	)
+	check(t, s.OrthogonalToBCounterClockwise().Equals(sExpected), "OrthogonalToBCounterClockwise")
+
+	// OrthogonalToBCentered
+	sExpected = MakeSegment2(
+		MakeVector2(-2.5000, 1.7500),
+		MakeVector2(-3.5000, 3.2500),
+	)
+	check(t, s.OrthogonalToBCentered().Equals(sExpected), "OrthogonalToBCentered")
+
+	// OrthogonalToCenterClockwise
+	sExpected = MakeSegment2(
+		MakeVector2(-2.2500, 3.0000),
+		MakeVector2(-3.2500, 4.5000),
+	)
+	check(t, s.OrthogonalToCenterClockwise().Equals(sExpected), "OrthogonalToCenterClockwise")
+
+	// OrthogonalToCenterCounterClockwise
+	sExpected = MakeSegment2(
+		MakeVector2(-2.2500, 3.0000),
+		MakeVector2(-1.2500, 1.5000),
+	)
+	check(t, s.OrthogonalToCenterCounterClockwise().Equals(sExpected), "OrthogonalToCenterCounterClockwise")
+
+	// OrthogonalToCenterCentered
+	sExpected = MakeSegment2(
+		MakeVector2(-1.7500, 2.2500),
+		MakeVector2(-2.7500, 3.7500),
+	)
+	check(t, s.OrthogonalToCenterCentered().Equals(sExpected), "OrthogonalToCenterCentered")
+
+	// MoveCenterTo
+	sExpected = MakeSegment2(
+		MakeVector2(0.75, 0.5),
+		MakeVector2(-0.75, -0.5),
+	)
+	check(t, s.MoveCenterTo(MakeVector2(0, 0)).Equals(sExpected), "MoveCenterTo")
+}
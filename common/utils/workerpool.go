@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Bounded pool of long-lived goroutines
+type WorkerPool struct {
+	size int
+	jobs chan func()
+
+	stopped    bool
+	done       chan struct{} // closed on stop; releases the blocked submitters
+	submitting sync.WaitGroup
+	mutex      sync.RWMutex
+}
+
+// size <= 0 => GOMAXPROCS workers
+// queue: number of jobs that can be submitted without blocking
+func NewWorkerPool(size int, queue int) *WorkerPool {
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+
+	if queue < size {
+		queue = size
+	}
+
+	pool := &WorkerPool{
+		size: size,
+		jobs: make(chan func(), queue),
+		done: make(chan struct{}),
+	}
+
+	for i := 0; i < size; i++ {
+		go func() {
+			for job := range pool.jobs {
+				job()
+			}
+		}()
+	}
+
+	return pool
+}
+
+func (pool *WorkerPool) GetSize() int {
+	return pool.size
+}
+
+// Queues the job; blocks only if the queue is full
+// Jobs submitted once the pool is stopped, or still blocked when it stops, are dropped
+func (pool *WorkerPool) Submit(job func()) bool {
+	pool.mutex.RLock()
+	if pool.stopped {
+		pool.mutex.RUnlock()
+		return false
+	}
+
+	// the lock is not held while blocked, so that Stop does not wait for the queue
+	pool.submitting.Add(1)
+	pool.mutex.RUnlock()
+
+	defer pool.submitting.Done()
+
+	select {
+	case pool.jobs <- job:
+		return true
+	case <-pool.done:
+		return false
+	}
+}
+
+// Calls fn for every index in [0, n) on the workers; returns when all the calls are done
+// One job per worker, not per index: the workers pull the indexes
+func (pool *WorkerPool) Run(n int, fn func(i int)) {
+	if n <= 0 {
+		return
+	}
+
+	nbJobs := pool.size
+	if n < nbJobs {
+		nbJobs = n
+	}
+
+	var next int64 = -1
+	wg := sync.WaitGroup{}
+	wg.Add(nbJobs)
+
+	for j := 0; j < nbJobs; j++ {
+		submitted := pool.Submit(func() {
+			defer wg.Done()
+
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= n {
+					return
+				}
+
+				fn(i)
+			}
+		})
+
+		if !submitted {
+			wg.Done()
+		}
+	}
+
+	wg.Wait()
+}
+
+// The workers end once the queued jobs are done
+func (pool *WorkerPool) Stop() {
+	pool.mutex.Lock()
+
+	if pool.stopped {
+		pool.mutex.Unlock()
+		return
+	}
+
+	pool.stopped = true
+	close(pool.done)
+	pool.mutex.Unlock()
+
+	// no job can be queued once the blocked submitters are released
+	pool.submitting.Wait()
+	close(pool.jobs)
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPoolRun(t *testing.T) {
+	pool := NewWorkerPool(4, 0)
+	defer pool.Stop()
+
+	for _, n := range []int{0, 1, 3, 4, 100} {
+		calls := make([]int32, n)
+		pool.Run(n, func(i int) {
+			atomic.AddInt32(&calls[i], 1)
+		})
+
+		for i, nbCalls := range calls {
+			if nbCalls != 1 {
+				t.Fatalf("n=%d: index %d called %d times", n, i, nbCalls)
+			}
+		}
+	}
+}
+
+func TestWorkerPoolSubmitAfterStop(t *testing.T) {
+	pool := NewWorkerPool(1, 0)
+	pool.Stop()
+
+	if pool.Submit(func() {}) {
+		t.Fatal("job submitted to a stopped pool")
+	}
+
+	// does not block
+	pool.Run(10, func(i int) {})
+}
+
+func TestWorkerPoolStopReleasesBlockedSubmit(t *testing.T) {
+	pool := NewWorkerPool(1, 1)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	running := make(chan struct{})
+	pool.Submit(func() {
+		close(running)
+		<-release
+	})
+	<-running
+
+	// fills the queue
+	pool.Submit(func() {})
+
+	submitted := make(chan bool)
+	go func() {
+		submitted <- pool.Submit(func() {})
+	}()
+
+	stopped := make(chan struct{})
+	go func() {
+		// lets the submit block first
+		time.Sleep(10 * time.Millisecond)
+		pool.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop blocked by a full queue")
+	}
+
+	select {
+	case ok := <-submitted:
+		if ok {
+			t.Fatal("blocked job submitted to a stopped pool")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Submit still blocked after Stop")
+	}
+}
+
+func benchmarkWork(i int) int {
+	sum := 0
+	for j := 0; j < 1000; j++ {
+		sum += i * j
+	}
+
+	return sum
+}
+
+func BenchmarkWorkerPoolRun(b *testing.B) {
+	pool := NewWorkerPool(0, 0)
+	defer pool.Stop()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		pool.Run(200, func(i int) {
+			benchmarkWork(i)
+		})
+	}
+}
+
+// One goroutine per item, as systemPerception did
+func BenchmarkGoroutinePerItem(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		wg := sync.WaitGroup{}
+		wg.Add(200)
+
+		for j := 0; j < 200; j++ {
+			go func(j int) {
+				benchmarkWork(j)
+				wg.Done()
+			}(j)
+		}
+
+		wg.Wait()
+	}
+}
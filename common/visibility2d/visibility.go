@@ -30,7 +30,14 @@ func OnlyVisible(position [2]float64, perceptionitems []ObstacleSegment) []Obsta
 
 	visibility := makeVisibilityProcessor()
 
+	light := point{position[0] * rescaleFactor, position[1] * rescaleFactor}
+
 	for _, item := range scaledUpSegments {
+		if isSeenEdgeOn(light, item.Points[0], item.Points[1]) {
+			// no angular width: hides nothing, and cannot be seen
+			continue
+		}
+
 		visibility.AddSegment(
 			item.Points[0][0], item.Points[0][1],
 			item.Points[1][0], item.Points[1][1],
@@ -38,7 +45,8 @@ func OnlyVisible(position [2]float64, perceptionitems []ObstacleSegment) []Obsta
 		)
 	}
 
-	visibility.SetLightLocation(position[0], position[1])
+	// the light is scaled up with the segments
+	visibility.SetLightLocation(light[0], light[1])
 	visibility.Sweep()
 
 	for _, visibleSegment := range visibility.output {
@@ -330,6 +338,17 @@ func (visi *visibilityProcessor) Sweep() {
 	}
 }
 
+const edgeOnTolerance = 0.000000001 // sine of the angle under which the segment is seen
+
+// Zero length segments, and segments aligned with the light (including those touching it)
+func isSeenEdgeOn(light, a, b point) bool {
+	ax, ay := a[0]-light[0], a[1]-light[1]
+	bx, by := b[0]-light[0], b[1]-light[1]
+
+	cross := ax*by - ay*bx
+	return math.Abs(cross) <= edgeOnTolerance*math.Sqrt((ax*ax+ay*ay)*(bx*bx+by*by))
+}
+
 func lineIntersection(p1, p2, p3, p4 point) point {
 	// From http://paulbourke.net/geometry/lineline2d/
 	var s = ((p4[0]-p3[0])*(p1[1]-p3[1]) - (p4[1]-p3[1])*(p1[0]-p3[0])) / ((p4[1]-p3[1])*(p2[0]-p1[0]) - (p4[0]-p3[0])*(p2[1]-p1[1]))
@@ -359,6 +378,11 @@ func (visi *visibilityProcessor) addTriangle(angle1, angle2 float64, segment *vi
 	p2[1] = visi.center[1] + math.Sin(angle2)
 	var pEnd = lineIntersection(p3, p4, p1, p2)
 
+	if !isFinitePoint(pBegin) || !isFinitePoint(pEnd) {
+		// ray parallel to the segment; happens on segments almost aligned with the light
+		return
+	}
+
 	visi.output = append(visi.output, visibleSegment{
 		p1:              pBegin,
 		p2:              pEnd,
@@ -377,6 +401,10 @@ type ObstacleSegment struct {
 	UserData interface{}
 }
 
+func isFinitePoint(p point) bool {
+	return !math.IsNaN(p[0]) && !math.IsNaN(p[1]) && !math.IsInf(p[0], 0) && !math.IsInf(p[1], 0)
+}
+
 func distance(a, b [2]float64) float64 {
 	dx := a[0] - b[0]
 	dy := a[1] - b[1]
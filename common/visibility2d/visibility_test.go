@@ -0,0 +1,140 @@
+package visibility2d
+
+import (
+	"math"
+	"testing"
+)
+
+func makeObstacleSegment(ax, ay, bx, by float64, userData interface{}) ObstacleSegment {
+	return ObstacleSegment{
+		Points:   [2][2]float64{{ax, ay}, {bx, by}},
+		UserData: userData,
+	}
+}
+
+func visibleLengthByUserData(segments []ObstacleSegment) map[interface{}]float64 {
+	lengths := make(map[interface{}]float64)
+	for _, segment := range segments {
+		lengths[segment.UserData] += math.Sqrt(distance(segment.Points[0], segment.Points[1]))
+	}
+
+	return lengths
+}
+
+func TestOnlyVisibleOcclusion(t *testing.T) {
+	for _, position := range [][2]float64{{0, 0}, {3, -2}} {
+		near := makeObstacleSegment(position[0]-1, position[1]+1, position[0]+1, position[1]+1, "near")
+		far := makeObstacleSegment(position[0]-4, position[1]+4, position[0]+4, position[1]+4, "far")
+		hidden := makeObstacleSegment(position[0]-0.5, position[1]+3, position[0]+0.5, position[1]+3, "hidden")
+
+		lengths := visibleLengthByUserData(OnlyVisible(position, []ObstacleSegment{near, far, hidden}))
+
+		if math.Abs(lengths["near"]-2) > 0.001 {
+			t.Errorf("%v: visible length of the near segment = %f, expected 2", position, lengths["near"])
+		}
+
+		// the near segment hides the whole far segment
+		if math.Abs(lengths["far"]-0) > 0.001 {
+			t.Errorf("%v: visible length of the far segment = %f, expected 0", position, lengths["far"])
+		}
+
+		if lengths["hidden"] != 0 {
+			t.Errorf("%v: visible length of the hidden segment = %f, expected 0", position, lengths["hidden"])
+		}
+	}
+}
+
+func TestOnlyVisiblePartialOcclusion(t *testing.T) {
+	near := makeObstacleSegment(-1, 1, 1, 1, "near")
+	far := makeObstacleSegment(-4, 2, 4, 2, "far")
+
+	lengths := visibleLengthByUserData(OnlyVisible([2]float64{0, 0}, []ObstacleSegment{near, far}))
+
+	// [-2, 2] is hidden on the far segment
+	if math.Abs(lengths["far"]-4) > 0.001 {
+		t.Errorf("visible length of the far segment = %f, expected 4", lengths["far"])
+	}
+}
+
+func TestBreakIntersections(t *testing.T) {
+	cross := []ObstacleSegment{
+		makeObstacleSegment(-1, 0, 1, 0, 0),
+		makeObstacleSegment(0, -1, 0, 1, 1),
+	}
+
+	broken := breakIntersections(cross)
+	if len(broken) != 4 {
+		t.Fatalf("%d segments, expected 4", len(broken))
+	}
+
+	lengths := visibleLengthByUserData(broken)
+	if math.Abs(lengths[0]-2) > epsilon || math.Abs(lengths[1]-2) > epsilon {
+		t.Errorf("broken segments lengths = %v, expected 2 each", lengths)
+	}
+}
+
+func pointSegmentDistance(p point, a, b [2]float64) float64 {
+	abx, aby := b[0]-a[0], b[1]-a[1]
+
+	lengthSq := abx*abx + aby*aby
+	if lengthSq == 0 {
+		return math.Sqrt(distance(p, a))
+	}
+
+	t := math.Max(0, math.Min(1, ((p[0]-a[0])*abx+(p[1]-a[1])*aby)/lengthSq))
+	return math.Sqrt(distance(p, [2]float64{a[0] + t*abx, a[1] + t*aby}))
+}
+
+// Each segment is 4 int8 coordinates, in tenths of units
+func decodeFuzzedSegments(data []byte) []ObstacleSegment {
+	segments := make([]ObstacleSegment, 0, len(data)/4)
+
+	for i := 0; i+4 <= len(data) && len(segments) < 32; i += 4 {
+		segments = append(segments, makeObstacleSegment(
+			float64(int8(data[i]))/10,
+			float64(int8(data[i+1]))/10,
+			float64(int8(data[i+2]))/10,
+			float64(int8(data[i+3]))/10,
+			len(segments),
+		))
+	}
+
+	return segments
+}
+
+func FuzzOnlyVisible(f *testing.F) {
+	f.Add(int16(0), int16(0), []byte{246, 10, 10, 10, 216, 40, 40, 40})             // near segment hiding a far one
+	f.Add(int16(0), int16(0), []byte{246, 0, 10, 0, 0, 246, 0, 10})                 // crossing segments
+	f.Add(int16(0), int16(0), []byte{10, 10, 10, 10})                               // zero length segment
+	f.Add(int16(0), int16(0), []byte{10, 10, 20, 20})                               // segment pointing at the position
+	f.Add(int16(5), int16(5), []byte{246, 10, 10, 10, 246, 10, 10, 10})             // same segment twice
+	f.Add(int16(0), int16(0), []byte{0, 0, 10, 10, 246, 10, 10, 246, 20, 0, 0, 20}) // segment starting at the position
+
+	f.Fuzz(func(t *testing.T, x int16, y int16, data []byte) {
+		position := [2]float64{float64(x) / 100, float64(y) / 100}
+		segments := decodeFuzzedSegments(data)
+
+		visible := OnlyVisible(position, segments)
+
+		for _, segment := range visible {
+			for _, point := range segment.Points {
+				if math.IsNaN(point[0]) || math.IsNaN(point[1]) || math.IsInf(point[0], 0) || math.IsInf(point[1], 0) {
+					t.Fatalf("non finite visible segment %v", segment.Points)
+				}
+			}
+
+			num, ok := segment.UserData.(int)
+			if !ok || num < 0 || num >= len(segments) {
+				t.Fatalf("visible segment %v has unknown user data %v", segment.Points, segment.UserData)
+			}
+
+			// visible parts are cut from the segments they come from
+			source := segments[num].Points
+			for _, p := range segment.Points {
+				if d := pointSegmentDistance(p, source[0], source[1]); d > 0.001 {
+					t.Fatalf("visible segment %v is %f away from its source %v", segment.Points, d, source)
+				}
+			}
+		}
+	})
+}
@@ -1,10 +1,13 @@
 package common
 
 import (
+	"time"
+
 	"github.com/bytearena/ecs"
 
 	"github.com/bytearena/core/common/types"
-	"github.com/bytearena/core/common/utils/vector"
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/common/utils/space"
 )
 
 type GameEventSubscription int32
@@ -12,11 +15,17 @@ type GameEventSubscription int32
 type GameInterface interface {
 	ImplementsGameInterface()
 
-	Initialize(cbkGameOver func())
+	Initialize(cbkGameOver func(reason string))
+	SetWallClockLimit(limit time.Duration)
+	TearDown()
+
+	// Problems found in the map when loading it; the polygons that could not be repaired are not loaded
+	GetMapGeometryIssues() []mapcontainer.MapGeometryIssue
 
 	Step(tickturn int, dt float64, mutations []types.AgentMutationBatch)
-	NewEntityAgent(contestant *types.Agent, pos vector.Vector2) ecs.EntityID
+	NewEntityAgent(contestant *types.Agent, pos space.MapVector2) ecs.EntityID
 	RemoveEntityAgent(contestant *types.Agent)
+	NotifyAgentRestarted(contestant *types.Agent, restarts int)
 
 	GetAgentPerception(entityid ecs.EntityID) []byte
 	GetAgentWelcome(entityid ecs.EntityID) []byte
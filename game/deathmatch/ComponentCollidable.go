@@ -39,6 +39,11 @@ func (collidable *Collidable) MayCollideWith(othercollidable *Collidable) bool {
 	return collidable.collideswith.Includes(othercollidable.collisiongroup)
 }
 
+// Grounds and obstacles
+func (collidable *Collidable) IsWall() bool {
+	return collidable.collisiongroup == CollisionGroup.Ground || collidable.collisiongroup == CollisionGroup.Obstacle
+}
+
 func (collidable *Collidable) CollisionScript(game *DeathmatchGame, entityID ecs.EntityID, otherEntityID ecs.EntityID, collidableAspect *Collidable, otherCollidableAspectB *Collidable, point vector.Vector2) {
 	if collidable.collisionScriptFunc == nil {
 		return
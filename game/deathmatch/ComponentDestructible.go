@@ -0,0 +1,10 @@
+package deathmatch
+
+// Map obstacles that can be worn down by projectiles
+type Destructible struct {
+	mapObjectID string // Const; id of the obstacle in the map
+}
+
+func (destructible Destructible) GetMapObjectID() string {
+	return destructible.mapObjectID
+}
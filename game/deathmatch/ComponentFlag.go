@@ -0,0 +1,27 @@
+package deathmatch
+
+import (
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/common/utils/space"
+)
+
+type Flag struct {
+	team    string
+	home    space.PhysicalVector2
+	carrier ecs.EntityID // valid only if carried
+	carried bool
+}
+
+func (flag Flag) GetTeam() string {
+	return flag.team
+}
+
+func (flag Flag) GetCarrier() (ecs.EntityID, bool) {
+	return flag.carrier, flag.carried
+}
+
+// Flags are never dropped (return-on-death): a flag that is not carried is at home
+func (flag Flag) IsAtHome() bool {
+	return !flag.carried
+}
@@ -1,9 +1,19 @@
 package deathmatch
 
 type Impactor struct {
-	damage float64
+	damage       float64
+	splashRadius float64 // in meter; 0 => no splash
+	splashDamage float64
 }
 
 func (o Impactor) GetDamage() float64 {
 	return o.damage
 }
+
+func (o Impactor) GetSplashRadius() float64 {
+	return o.splashRadius
+}
+
+func (o Impactor) GetSplashDamage() float64 {
+	return o.splashDamage
+}
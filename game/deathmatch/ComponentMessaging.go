@@ -0,0 +1,57 @@
+package deathmatch
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+var sayChannel = struct {
+	Team   string
+	Radius string
+}{
+	Team:   "team",
+	Radius: "radius",
+}
+
+type pendingSay struct {
+	payload json.RawMessage
+	channel string
+	radius  float64 // radius channel only; agent referential
+}
+
+type Messaging struct {
+	pendingSays  []pendingSay
+	pendingBytes int // said during the current tick
+
+	MaxPayloadSize  int     // Const; in bytes, for one message
+	MaxBytesPerTick int     // Const; in bytes, for all the messages of a tick
+	MaxRadius       float64 // Const; range of the radius channel, in m
+}
+
+func (messaging *Messaging) PushSay(payload json.RawMessage, channel string, radius float64) error {
+	if len(payload) > messaging.MaxPayloadSize {
+		return errors.New("Payload too large (" + strconv.Itoa(len(payload)) + " bytes, max " + strconv.Itoa(messaging.MaxPayloadSize) + ")")
+	}
+
+	if messaging.pendingBytes+len(payload) > messaging.MaxBytesPerTick {
+		return errors.New("Too many bytes said during this tick (max " + strconv.Itoa(messaging.MaxBytesPerTick) + ")")
+	}
+
+	messaging.pendingBytes += len(payload)
+	messaging.pendingSays = append(messaging.pendingSays, pendingSay{
+		payload: payload,
+		channel: channel,
+		radius:  radius,
+	})
+
+	return nil
+}
+
+func (messaging *Messaging) PopPendingSays() []pendingSay {
+	res := messaging.pendingSays
+	messaging.pendingSays = make([]pendingSay, 0)
+	messaging.pendingBytes = 0
+
+	return res
+}
@@ -0,0 +1,57 @@
+package deathmatch
+
+import (
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+// Scripted motion of kinematic obstacles
+type Motion struct {
+	mapObjectID string // Const; id of the obstacle in the map
+
+	waypoints    []vector.Vector2 // Const; physical referential; the first one is the starting point
+	speed        float64          // Const; physical referential, in m/tick
+	loop         bool             // Const
+	nextWaypoint int
+	backwards    bool // when going back and forth
+
+	angularSpeed float64 // Const; in rad/tick
+	rotateFor    int     // Const; in ticks; 0 => rotates continuously
+	pauseFor     int     // Const; in ticks
+}
+
+func (motion Motion) GetMapObjectID() string {
+	return motion.mapObjectID
+}
+
+func (motion *Motion) advanceWaypoint() {
+	last := len(motion.waypoints) - 1
+
+	if motion.loop {
+		motion.nextWaypoint = (motion.nextWaypoint + 1) % len(motion.waypoints)
+		return
+	}
+
+	if motion.nextWaypoint == last {
+		motion.backwards = true
+	} else if motion.nextWaypoint == 0 {
+		motion.backwards = false
+	}
+
+	if motion.backwards {
+		motion.nextWaypoint--
+	} else {
+		motion.nextWaypoint++
+	}
+}
+
+func (motion Motion) isRotating(ticknum int) bool {
+	if motion.angularSpeed == 0 {
+		return false
+	}
+
+	if motion.rotateFor <= 0 {
+		return true
+	}
+
+	return ticknum%(motion.rotateFor+motion.pauseFor) < motion.rotateFor
+}
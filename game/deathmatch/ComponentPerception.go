@@ -1,9 +1,25 @@
 package deathmatch
 
+import (
+	"math/rand"
+
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/common/utils/vector"
+)
+
 type Perception struct {
 	visionAngle  float64 // expressed in rad
 	visionRadius float64 // expressed in rad
 	perception   *agentPerception
+
+	rangefinder *Rangefinder // nil if not equipped
+	hearing     *Hearing     // nil if not equipped
+
+	rnd       *rand.Rand                  // perception noise; nil if the perception is exact
+	farVision []agentPerceptionVisionItem // far items, as perceived at their last refresh
+
+	scratch *perceptionScratch // buffers reused by the computation of the perception
 }
 
 func (p Perception) GetVisionAngle() float64 {
@@ -22,3 +38,59 @@ func (p *Perception) SetPerception(perception *agentPerception) *Perception {
 func (p Perception) GetPerception() *agentPerception {
 	return p.perception
 }
+
+func (p *Perception) getScratch() *perceptionScratch {
+	if p.scratch == nil {
+		p.scratch = newPerceptionScratch()
+	}
+
+	return p.scratch
+}
+
+func (p Perception) GetRangefinder() *Rangefinder {
+	return p.rangefinder
+}
+
+func (p Perception) GetHearing() *Hearing {
+	return p.hearing
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Optional sensors, besides vision
+///////////////////////////////////////////////////////////////////////////////
+
+var sensorKind = struct {
+	Rangefinder string
+	Hearing     string
+}{
+	Rangefinder: "rangefinder",
+	Hearing:     "hearing",
+}
+
+// Fixed fan of raycasts, centered on the heading
+type Rangefinder struct {
+	rays   int     // Const; evenly spread over the angle
+	angle  float64 // Const; expressed in rad
+	range_ float64 // Const; expressed in m
+}
+
+// Shots and impacts heard around
+type Hearing struct {
+	radius float64 // Const; expressed in m
+}
+
+var soundKind = struct {
+	Shot   string
+	Impact string
+	Frag   string
+}{
+	Shot:   "shot",
+	Impact: "impact",
+	Frag:   "frag",
+}
+
+type sound struct {
+	kind     string
+	emitter  ecs.EntityID   // not heard by the emitter itself
+	position vector.Vector2 // agent referential
+}
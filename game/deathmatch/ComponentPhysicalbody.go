@@ -1,23 +1,17 @@
 package deathmatch
 
 import (
-	"github.com/go-gl/mathgl/mgl64"
-
 	"github.com/bytearena/box2d"
 
+	"github.com/bytearena/core/common/utils/space"
 	"github.com/bytearena/core/common/utils/vector"
 )
 
 type PhysicalBody struct {
 	body *box2d.B2Body
 
-	// 2 dimensional transform for points in space
-	pointTransformIn  *mgl64.Mat4
-	pointTransformOut *mgl64.Mat4
-
-	// 1 dimensional transform for distance; could be infered from 2 dimensional transforms, but easier / faster that way
-	distanceScaleIn  float64
-	distanceScaleOut float64
+	// points and lengths go between the physical and the agent space through these
+	spaces *space.Spaces
 
 	// 1 dimensional transform for time
 	timeScaleIn  float64
@@ -39,44 +33,39 @@ func (p *PhysicalBody) SetBody(body *box2d.B2Body) *PhysicalBody {
 	return p
 }
 
-func (p PhysicalBody) GetPhysicalReferentialPosition() vector.Vector2 {
-	v := p.body.GetPosition()
-	return vector.MakeVector2(v.X, v.Y)
+func (p PhysicalBody) GetPhysicalReferentialPosition() space.PhysicalVector2 {
+	return space.PhysicalFromB2Vec2(p.body.GetPosition())
 }
 
+// Agent space
 func (p PhysicalBody) GetPosition() vector.Vector2 {
-	v := p.body.GetPosition()
-	return vector.MakeVector2(v.X, v.Y).Transform(p.pointTransformOut)
+	return p.spaces.PhysicalToAgent(p.GetPhysicalReferentialPosition()).Vector2()
 }
 
-func (p *PhysicalBody) SetPosition(v vector.Vector2) *PhysicalBody {
-	p.body.SetTransform(v.Transform(p.pointTransformIn).ToB2Vec2(), p.GetOrientation())
-	return p
+func (p *PhysicalBody) SetPosition(v space.AgentVector2) *PhysicalBody {
+	return p.SetPositionInPhysicalScale(p.spaces.AgentToPhysical(v))
 }
 
-func (p *PhysicalBody) SetPositionInPhysicalScale(v vector.Vector2) *PhysicalBody {
+func (p *PhysicalBody) SetPositionInPhysicalScale(v space.PhysicalVector2) *PhysicalBody {
 	p.body.SetTransform(v.ToB2Vec2(), p.GetOrientation())
 	return p
 }
 
-func (p PhysicalBody) GetPhysicalReferentialVelocity() vector.Vector2 {
-	v := p.body.GetLinearVelocity()
-	return vector.MakeVector2(v.X, v.Y)
+func (p PhysicalBody) GetPhysicalReferentialVelocity() space.PhysicalVector2 {
+	return space.PhysicalFromB2Vec2(p.body.GetLinearVelocity())
 }
 
 func (p PhysicalBody) GetVelocity() vector.Vector2 {
 
-	v := p.body.GetLinearVelocity()
-
 	// In Box2D, velocity is expressed in m/s in physics scale
 	// In Game, velocity is expressed in m/tick in agent scale
 
 	// Box2D => game : v * timescaleOut * transformOut
 
-	return vector.
-		MakeVector2(v.X, v.Y).
-		Scale(p.timeScaleOut).
-		Transform(p.pointTransformOut)
+	return p.spaces.
+		PhysicalToAgentDirection(p.GetPhysicalReferentialVelocity()).
+		Vector2().
+		Scale(p.timeScaleOut)
 }
 
 func (p *PhysicalBody) SetVelocity(v vector.Vector2) *PhysicalBody {
@@ -86,9 +75,8 @@ func (p *PhysicalBody) SetVelocity(v vector.Vector2) *PhysicalBody {
 
 	// Game => Box2D : v * timeScaleIn * transformIn
 
-	box2dvelocity := v.
-		Scale(p.timeScaleIn).
-		Transform(p.pointTransformIn).
+	box2dvelocity := p.spaces.
+		AgentToPhysicalDirection(space.MakeAgentVector2FromVector2(v.Scale(p.timeScaleIn))).
 		ToB2Vec2()
 
 	p.body.SetLinearVelocity(box2dvelocity)
@@ -96,6 +84,16 @@ func (p *PhysicalBody) SetVelocity(v vector.Vector2) *PhysicalBody {
 	return p
 }
 
+// Velocity of a point of the body, including rotation; expressed like GetVelocity
+func (p PhysicalBody) GetVelocityAtPhysicalReferentialPoint(point space.PhysicalVector2) vector.Vector2 {
+	v := space.PhysicalFromB2Vec2(p.body.GetLinearVelocityFromWorldPoint(point.ToB2Vec2()))
+
+	return p.spaces.
+		PhysicalToAgentDirection(v).
+		Vector2().
+		Scale(p.timeScaleOut)
+}
+
 func (p PhysicalBody) GetPhysicalReferentialOrientation() float64 {
 	return p.body.GetAngle()
 }
@@ -116,7 +114,7 @@ func (p PhysicalBody) GetPhysicalReferentialRadius() float64 {
 
 func (p PhysicalBody) GetRadius() float64 {
 	// here we suppose that the body is always a circle
-	return p.body.GetFixtureList().GetShape().GetRadius() * p.distanceScaleOut
+	return p.spaces.PhysicalToAgentDistance(p.body.GetFixtureList().GetShape().GetRadius())
 }
 
 func (p PhysicalBody) GetMaxSpeed() float64 {
@@ -0,0 +1,35 @@
+package deathmatch
+
+var pickupKind = struct {
+	Health string
+	Energy string
+	Speed  string
+}{
+	Health: "health",
+	Energy: "energy",
+	Speed:  "speed",
+}
+
+type Pickup struct {
+	kind         string
+	amount       float64 // Const; life or energy restored, or speed factor
+	duration     int     // Const; duration of the effect, in ticks (0 => instant)
+	respawnDelay int     // Const; ticks before the pickup is available again
+
+	available bool
+	respawnAt int // tick at which the pickup becomes available again
+}
+
+func (pickup Pickup) GetKind() string {
+	return pickup.kind
+}
+
+func (pickup Pickup) IsAvailable() bool {
+	return pickup.available
+}
+
+// Timed speed boost applied on an agent by a speed pickup
+type SpeedBoost struct {
+	baseMaxSpeed float64
+	until        int // tick at which the boost expires
+}
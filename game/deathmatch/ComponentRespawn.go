@@ -4,5 +4,6 @@ type Respawn struct {
 	isRespawning        bool
 	respawningCountdown int
 	respawnCount        int
+	eliminated          bool // last agent standing; will not respawn
 	onRespawn           func()
 }
@@ -6,20 +6,23 @@ import (
 	"github.com/bytearena/core/common/utils/vector"
 )
 
+type pendingShot struct {
+	aiming vector.Vector2
+	weapon string
+}
+
 type Shooting struct {
-	pendingShots []vector.Vector2
+	pendingShots []pendingShot
 	lock         *sync.RWMutex
 
 	MaxShootEnergy    float64 // Const; When shooting, energy decreases
 	ShootEnergy       float64 // Current energy level
 	ShootRecoveryRate float64 // Const; Energy regained every tick
-	ShootCost         float64 // Const; Energy consumed by a shot
-	ShootCooldown     int     // Const; number of ticks to wait between every shot
 	LastShot          int     // Number of ticks since last shot
+	LastShotCooldown  int     // Cooldown of the weapon used for the last shot
 
-	ProjectileSpeed  float64 // Const; expressed in m/tick
-	ProjectileDamage float64 // Const; amount of life consumed on impact
-	ProjectileRange  float64 // Const, in meter
+	DefaultWeapon string             // Const; weapon used when the shot does not name one
+	Weapons       map[string]*Weapon // Const; weapons of the agent, by name
 }
 
 func BuildShooting(shooting *Shooting) *Shooting {
@@ -27,16 +30,31 @@ func BuildShooting(shooting *Shooting) *Shooting {
 	return shooting
 }
 
-func (shooting *Shooting) PushShot(aiming vector.Vector2) {
+func (shooting *Shooting) GetWeapon(name string) *Weapon {
+	if name == "" {
+		name = shooting.DefaultWeapon
+	}
+
+	if weapon, ok := shooting.Weapons[name]; ok {
+		return weapon
+	}
+
+	return nil
+}
+
+func (shooting *Shooting) PushShot(aiming vector.Vector2, weapon string) {
 	shooting.lock.Lock()
-	shooting.pendingShots = append(shooting.pendingShots, aiming)
+	shooting.pendingShots = append(shooting.pendingShots, pendingShot{
+		aiming: aiming,
+		weapon: weapon,
+	})
 	shooting.lock.Unlock()
 }
 
-func (shooting *Shooting) PopPendingShots() []vector.Vector2 {
+func (shooting *Shooting) PopPendingShots() []pendingShot {
 	shooting.lock.RLock()
 	res := shooting.pendingShots
-	shooting.pendingShots = make([]vector.Vector2, 0)
+	shooting.pendingShots = make([]pendingShot, 0)
 	shooting.lock.RUnlock()
 
 	return res
@@ -0,0 +1,21 @@
+package deathmatch
+
+type Team struct {
+	name string
+}
+
+func (team Team) GetName() string {
+	return team.name
+}
+
+// Teams are assigned round-robin, in order of arrival
+func (deathmatch *DeathmatchGame) nextTeam() string {
+	if len(deathmatch.teams) == 0 {
+		return ""
+	}
+
+	team := deathmatch.teams[deathmatch.nbTeamsAssigned%len(deathmatch.teams)]
+	deathmatch.nbTeamsAssigned++
+
+	return team
+}
@@ -9,13 +9,14 @@ import (
 	"github.com/bytearena/core/common/types"
 	"github.com/bytearena/core/common/utils"
 	"github.com/bytearena/core/common/utils/number"
+	"github.com/bytearena/core/common/utils/space"
 	"github.com/bytearena/core/common/utils/vector"
 	"github.com/bytearena/core/game/deathmatch/events"
 )
 
 func (deathmatch *DeathmatchGame) NewEntityAgent(
 	agent *types.Agent,
-	spawnPosition vector.Vector2, // spawnPosition in physical space; TODO: fix this, should be in agent space
+	spawnPosition space.MapVector2,
 ) ecs.EntityID {
 	agentEntity := deathmatch.manager.NewEntity()
 
@@ -29,12 +30,22 @@ func (deathmatch *DeathmatchGame) NewEntityAgent(
 	bodyRadius := 0.5
 	maxSpeed := 1.25
 	maxSteering := 10000.0
-	dragForce := 0.015
+	dragForce := deathmatch.physics.drag
 	maxAngularVelocity := number.DegreeToRadian(15.0)
 
 	visionRadius := 150.0
 	visionAngle := number.DegreeToRadian(160)
 
+	var rangefinder *Rangefinder
+	if deathmatch.sensors[sensorKind.Rangefinder] {
+		rangefinder = MakeDefaultRangefinder()
+	}
+
+	var hearing *Hearing
+	if deathmatch.sensors[sensorKind.Hearing] {
+		hearing = MakeDefaultHearing()
+	}
+
 	///////////////////////////////////////////////////////////////////////////
 	// Création du corps physique de l'agent (Box2D)
 	///////////////////////////////////////////////////////////////////////////
@@ -47,11 +58,11 @@ func (deathmatch *DeathmatchGame) NewEntityAgent(
 	body := deathmatch.PhysicalWorld.CreateBody(&bodydef)
 
 	shape := box2d.MakeB2CircleShape()
-	shape.SetRadius(bodyRadius * deathmatch.physicalToAgentSpaceInverseScale)
+	shape.SetRadius(deathmatch.spaces.AgentToPhysicalDistance(bodyRadius))
 
 	fixturedef := box2d.MakeB2FixtureDef()
 	fixturedef.Shape = &shape
-	fixturedef.Density = 20.0
+	fixturedef.Density = deathmatch.physics.getAgentDensity(shape.GetRadius())
 	body.CreateFixtureFromDef(&fixturedef)
 	body.SetUserData(types.MakePhysicalBodyDescriptor(
 		types.PhysicalBodyDescriptorType.Agent,
@@ -59,6 +70,11 @@ func (deathmatch *DeathmatchGame) NewEntityAgent(
 	))
 	body.SetBullet(false)
 
+	var rnd *rand.Rand
+	if deathmatch.perceptionNoise != nil {
+		rnd = deathmatch.perceptionNoise.makeRand(agentEntity.GetID())
+	}
+
 	///////////////////////////////////////////////////////////////////////////
 	// Composition de l'agent dans l'ECS
 	///////////////////////////////////////////////////////////////////////////
@@ -71,21 +87,20 @@ func (deathmatch *DeathmatchGame) NewEntityAgent(
 			maxAngularVelocity: maxAngularVelocity,
 			dragForce:          dragForce,
 
-			pointTransformIn:  deathmatch.physicalToAgentSpaceInverseTransform,
-			pointTransformOut: deathmatch.physicalToAgentSpaceTransform,
-
-			distanceScaleIn:  deathmatch.physicalToAgentSpaceInverseScale, // same as transform matrix, but scale only (for 1D transforms of length)
-			distanceScaleOut: deathmatch.physicalToAgentSpaceScale,        // same as transform matrix, but scale only (for 1D transforms of length)
+			spaces: deathmatch.spaces,
 
 			timeScaleIn:  float64(tps),       // m/tick to m/s; => ticksPerSecond
 			timeScaleOut: 1.0 / float64(tps), // m/s to m/tick; => 1 / ticksPerSecond
 		}).SetPositionInPhysicalScale(
-			vector.MakeVector2(spawnPosition.GetX(), -1*spawnPosition.GetY()), // TODO(jerome): invert axes in transform, not here
+			deathmatch.spaces.MapToPhysical(spawnPosition),
 		)).
 		AddComponent(deathmatch.perceptionComponent, &Perception{
 			visionAngle:  visionAngle,
 			visionRadius: visionRadius,
 			perception:   newEmptyAgentPerception(),
+			rangefinder:  rangefinder,
+			hearing:      hearing,
+			rnd:          rnd,
 		}).
 		AddComponent(deathmatch.healthComponent, &Health{
 			maxLife: 1000, // Const
@@ -103,13 +118,11 @@ func (deathmatch *DeathmatchGame) NewEntityAgent(
 			MaxShootEnergy:    1000, // Const; When shooting, energy decreases
 			ShootEnergy:       1000, // Current energy level
 			ShootRecoveryRate: 10,   // Const; Energy regained every tick; 10 => reconstituted in 100 ticks
-			ShootCooldown:     3,    // Const; number of ticks to wait between every shot
-			ShootCost:         200,  // Const
 			LastShot:          0,    // Number of ticks since last shot; 0 => cannot shoot immediately, must wait for first cooldown
+			LastShotCooldown:  3,    // Cooldown before the first shot
 
-			ProjectileSpeed:  15,   // Const; m/tick
-			ProjectileDamage: 400,  // Const; amount of life consumed on target when projectile hits
-			ProjectileRange:  1200, // in m
+			DefaultWeapon: weaponKind.Gun,
+			Weapons:       MakeDefaultWeapons(),
 		})).
 		AddComponent(deathmatch.steeringComponent, NewSteering(
 			maxSteering, // MaxSteering
@@ -137,6 +150,11 @@ func (deathmatch *DeathmatchGame) NewEntityAgent(
 				lifecycleAspect := qr.Components[deathmatch.lifecycleComponent].(*Lifecycle)
 				lifecycleAspect.locked = true
 
+				if respawnAspect.eliminated {
+					// out of the match; stays locked
+					return
+				}
+
 				respawnAspect.isRespawning = true
 				respawnAspect.respawningCountdown = deathmatch.gameDescription.GetTps() * 5 // 5 seconds
 
@@ -169,7 +187,7 @@ func (deathmatch *DeathmatchGame) NewEntityAgent(
 				healthAspect := qr.Components[deathmatch.healthComponent].(*Health)
 
 				physicalAspect.SetPositionInPhysicalScale(
-					vector.MakeVector2(spawnPoint.GetX(), spawnPoint.GetY()),
+					deathmatch.spaces.MapToPhysical(spawnPoint.ToMapVector2()),
 				)
 				lifecycleAspect.locked = false
 				healthAspect.Restore()
@@ -180,7 +198,18 @@ func (deathmatch *DeathmatchGame) NewEntityAgent(
 				})
 			},
 		}).
-		AddComponent(deathmatch.mailboxComponent, &Mailbox{})
+		AddComponent(deathmatch.mailboxComponent, &Mailbox{}).
+		AddComponent(deathmatch.messagingComponent, &Messaging{
+			MaxPayloadSize:  256, // Const; in bytes
+			MaxBytesPerTick: 512, // Const; in bytes
+			MaxRadius:       100, // Const; in m
+		})
+
+	if len(deathmatch.teams) > 0 {
+		agentEntity.AddComponent(deathmatch.teamComponent, &Team{
+			name: deathmatch.nextTeam(),
+		})
+	}
 
 	return agentEntity.GetID()
 }
@@ -191,7 +220,24 @@ func (deathmatch *DeathmatchGame) RemoveEntityAgent(agent *types.Agent) {
 	deathmatch.manager.DisposeEntity(qr)
 }
 
+func (deathmatch *DeathmatchGame) NotifyAgentRestarted(agent *types.Agent, restarts int) {
+	deathmatch.BusPublish(events.EntityRestarted{
+		Entity:   agent.EntityID,
+		Restarts: restarts,
+	})
+}
+
 func agentCollisionScript(game *DeathmatchGame, entityID ecs.EntityID, otherEntityID ecs.EntityID, collidableAspect *Collidable, otherCollidableAspectB *Collidable, point vector.Vector2) {
+	if otherCollidableAspectB.isSensor {
+		// sensors (pickups, exits) do not stop agents
+		return
+	}
+
+	if game.physics.wallRestitution > 0 && otherCollidableAspectB.IsWall() {
+		// bouncing; Box2D already reflected the velocity
+		return
+	}
+
 	entityResult := game.getEntity(entityID, game.physicalBodyComponent)
 	if entityResult == nil {
 		return
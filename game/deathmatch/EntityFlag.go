@@ -0,0 +1,65 @@
+package deathmatch
+
+import (
+	"github.com/bytearena/box2d"
+	"github.com/bytearena/ecs"
+
+	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/common/utils"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+func (deathmatch *DeathmatchGame) NewEntityFlag(point mapcontainer.MapPoint, team string) *ecs.Entity {
+
+	flag := deathmatch.manager.NewEntity()
+
+	bodyRadius := 1.0 // meters
+	home := deathmatch.spaces.MapToPhysical(point.ToMapVector2())
+
+	bodydef := box2d.MakeB2BodyDef()
+	bodydef.Type = box2d.B2BodyType.B2_kinematicBody // moved with its carrier
+	bodydef.Position = home.ToB2Vec2()
+
+	body := deathmatch.PhysicalWorld.CreateBody(&bodydef)
+	body.SetUserData(commontypes.MakePhysicalBodyDescriptor(
+		commontypes.PhysicalBodyDescriptorType.Flag,
+		flag.GetID(),
+	))
+
+	shape := box2d.MakeB2CircleShape()
+	shape.SetRadius(deathmatch.spaces.AgentToPhysicalDistance(bodyRadius))
+
+	fixturedef := box2d.MakeB2FixtureDef()
+	fixturedef.Shape = &shape
+	fixturedef.IsSensor = true
+
+	body.CreateFixtureFromDef(&fixturedef)
+
+	return flag.
+		AddComponent(deathmatch.physicalBodyComponent, &PhysicalBody{
+			body: body,
+
+			spaces: deathmatch.spaces,
+		}).
+		AddComponent(deathmatch.renderComponent, &Render{
+			type_:  "flag:" + team,
+			static: false,
+		}).
+		AddComponent(deathmatch.flagComponent, &Flag{
+			team: team,
+			home: home,
+		}).
+		AddComponent(deathmatch.collidableComponent, &Collidable{
+			collisiongroup: CollisionGroup.Sensor,
+			collideswith: utils.BuildTag(
+				CollisionGroup.Agent,
+			),
+			isSensor:            true,
+			collisionScriptFunc: flagCollisionScript,
+		})
+}
+
+func flagCollisionScript(game *DeathmatchGame, entityID ecs.EntityID, otherEntityID ecs.EntityID, collidableAspect *Collidable, otherCollidableAspectB *Collidable, point vector.Vector2) {
+	game.touchFlag(otherEntityID, entityID)
+}
@@ -2,6 +2,8 @@ package deathmatch
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/bytearena/box2d"
 	"github.com/bytearena/ecs"
@@ -9,10 +11,13 @@ import (
 	commontypes "github.com/bytearena/core/common/types"
 	"github.com/bytearena/core/common/types/mapcontainer"
 	"github.com/bytearena/core/common/utils"
+	"github.com/bytearena/core/common/utils/polygon"
+	"github.com/bytearena/core/common/utils/space"
+	"github.com/bytearena/core/common/utils/vector"
 )
 
 func (deathmatch *DeathmatchGame) NewEntityGround(polygon mapcontainer.MapPolygon, name string) *ecs.Entity {
-	return newEntityGroundOrObstacle(deathmatch, polygon, commontypes.PhysicalBodyDescriptorType.Ground, name).
+	return newEntityGroundOrObstacle(deathmatch, polygon, commontypes.PhysicalBodyDescriptorType.Ground, name, box2d.B2BodyType.B2_staticBody, space.MakePhysicalVector2(0, 0)).
 		AddComponent(deathmatch.collidableComponent, &Collidable{
 			collisiongroup: CollisionGroup.Ground,
 			collideswith: utils.BuildTag(
@@ -22,7 +27,73 @@ func (deathmatch *DeathmatchGame) NewEntityGround(polygon mapcontainer.MapPolygo
 }
 
 func (deathmatch *DeathmatchGame) NewEntityObstacle(polygon mapcontainer.MapPolygon, name string) *ecs.Entity {
-	return newEntityGroundOrObstacle(deathmatch, polygon, commontypes.PhysicalBodyDescriptorType.Obstacle, name).
+	return newEntityObstacle(deathmatch, polygon, name, box2d.B2BodyType.B2_staticBody, space.MakePhysicalVector2(0, 0))
+}
+
+// Static or kinematic, depending on the motion described in the map
+func (deathmatch *DeathmatchGame) NewEntityMapObstacle(obstacle mapcontainer.MapPolygonObject) *ecs.Entity {
+	var entity *ecs.Entity
+	if obstacle.Motion != nil {
+		entity = deathmatch.NewEntityKinematicObstacle(obstacle)
+	} else {
+		entity = deathmatch.NewEntityObstacle(obstacle.Polygon, obstacle.Name)
+	}
+
+	if hasSolidTag(obstacle.Tags) {
+		makeSolidObstacle(deathmatch, entity.GetID(), obstacle.Name)
+	}
+
+	return entity
+}
+
+func (deathmatch *DeathmatchGame) NewEntityKinematicObstacle(obstacle mapcontainer.MapPolygonObject) *ecs.Entity {
+
+	motion := obstacle.Motion
+
+	var mapPivot space.MapVector2
+	if motion.Pivot != nil {
+		mapPivot = motion.Pivot.ToMapVector2()
+	} else {
+		mapPivot = getPolygonCentroid(obstacle.Polygon)
+	}
+	pivot := deathmatch.spaces.MapToPhysical(mapPivot)
+
+	// physical referential
+	waypoints := []vector.Vector2{pivot.Vector2()}
+	for _, point := range motion.Path {
+		waypoints = append(waypoints, deathmatch.spaces.MapToPhysical(point.ToMapVector2()).Vector2())
+	}
+
+	return newEntityObstacle(deathmatch, obstacle.Polygon, obstacle.Name, box2d.B2BodyType.B2_kinematicBody, pivot).
+		AddComponent(deathmatch.motionComponent, &Motion{
+			mapObjectID:  obstacle.Id,
+			waypoints:    waypoints,
+			speed:        motion.Speed,
+			loop:         motion.Loop,
+			nextWaypoint: 0,
+			angularSpeed: motion.AngularSpeed,
+			rotateFor:    motion.RotateFor,
+			pauseFor:     motion.PauseFor,
+		})
+}
+
+// Average of the vertices; good enough for a rotation center
+func getPolygonCentroid(polygon mapcontainer.MapPolygon) space.MapVector2 {
+	centroid := vector.MakeNullVector2()
+	if len(polygon.Points) == 0 {
+		return space.MakeMapVector2(0, 0)
+	}
+
+	for _, point := range polygon.Points {
+		centroid = centroid.Add(point.ToMapVector2().Vector2())
+	}
+
+	centroid = centroid.DivScalar(float64(len(polygon.Points)))
+	return space.MakeMapVector2(centroid.GetX(), centroid.GetY())
+}
+
+func newEntityObstacle(deathmatch *DeathmatchGame, polygon mapcontainer.MapPolygon, name string, bodytype uint8, origin space.PhysicalVector2) *ecs.Entity {
+	return newEntityGroundOrObstacle(deathmatch, polygon, commontypes.PhysicalBodyDescriptorType.Obstacle, name, bodytype, origin).
 		AddComponent(deathmatch.collidableComponent, &Collidable{
 			collisiongroup: CollisionGroup.Obstacle,
 			collideswith: utils.BuildTag(
@@ -32,18 +103,148 @@ func (deathmatch *DeathmatchGame) NewEntityObstacle(polygon mapcontainer.MapPoly
 		})
 }
 
-func newEntityGroundOrObstacle(deathmatch *DeathmatchGame, polygon mapcontainer.MapPolygon, obstacletype string, name string) *ecs.Entity {
+const destructibleTag = "destructible" // obstacle tag; destructible or destructible:<life>
+const destructibleDefaultLife = 1000
+
+// Returns the life of the obstacle if tagged as destructible
+func getDestructibleLifeFromTags(tags []string) (float64, bool) {
+	for _, tag := range tags {
+		if tag == destructibleTag {
+			return destructibleDefaultLife, true
+		}
+
+		if !strings.HasPrefix(tag, destructibleTag+":") {
+			continue
+		}
+
+		life, err := strconv.ParseFloat(strings.TrimPrefix(tag, destructibleTag+":"), 64)
+		if err != nil || life <= 0 {
+			return destructibleDefaultLife, true
+		}
+
+		return life, true
+	}
+
+	return 0, false
+}
+
+func (deathmatch *DeathmatchGame) NewEntityDestructibleObstacle(obstacle mapcontainer.MapPolygonObject, life float64) *ecs.Entity {
+
+	entity := deathmatch.NewEntityMapObstacle(obstacle)
+	entityID := entity.GetID()
+
+	return entity.
+		AddComponent(deathmatch.destructibleComponent, &Destructible{
+			mapObjectID: obstacle.Id,
+		}).
+		AddComponent(deathmatch.healthComponent, &Health{
+			maxLife: life,
+			life:    life,
+		}).
+		AddComponent(deathmatch.lifecycleComponent, &Lifecycle{
+			onDeath: func() {
+				qr := deathmatch.getEntity(entityID,
+					deathmatch.physicalBodyComponent,
+					deathmatch.lifecycleComponent,
+				)
+
+				if qr == nil {
+					return
+				}
+
+				// The entity is kept for its damage state; removing the fixtures
+				// takes the obstacle out of collisions, shots and vision
+				body := qr.Components[deathmatch.physicalBodyComponent].(*PhysicalBody).GetBody()
+				for fixture := body.GetFixtureList(); fixture != nil; {
+					next := fixture.GetNext()
+					body.DestroyFixture(fixture)
+					fixture = next
+				}
+
+				qr.Components[deathmatch.lifecycleComponent].(*Lifecycle).locked = true
+			},
+		})
+}
+
+const solidTag = "solid" // obstacle tag; the obstacle is filled, not only outlined
+const solidMinPieceArea = 0.0001
+
+// User data of the outline edges of solid obstacles: they are seen, but do not collide
+type solidOutline struct{}
+
+func hasSolidTag(tags []string) bool {
+	for _, tag := range tags {
+		if tag == solidTag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSolidOutline(fixture *box2d.B2Fixture) bool {
+	_, ok := fixture.GetUserData().(solidOutline)
+	return ok
+}
+
+// Fills the outline of the obstacle with convex polygons, so that bodies getting inside are pushed out
+// The outline edges are kept for the vision
+func makeSolidObstacle(deathmatch *DeathmatchGame, entityID ecs.EntityID, name string) {
+
+	qr := deathmatch.getEntity(entityID, deathmatch.physicalBodyComponent)
+	if qr == nil {
+		return
+	}
+
+	body := qr.Components[deathmatch.physicalBodyComponent].(*PhysicalBody).GetBody()
+
+	vertices := make([]vector.Vector2, 0)
+	for fixture := body.GetFixtureList(); fixture != nil; fixture = fixture.GetNext() {
+		if edge, ok := fixture.GetShape().(*box2d.B2EdgeShape); ok {
+			vertices = append(vertices, vector.FromB2Vec2(edge.M_vertex1))
+		}
+	}
+
+	pieces, err := polygon.DecomposeConvex(vertices, box2d.B2_maxPolygonVertices)
+	if err != nil {
+		utils.Debug("deathmatch-map", "Obstacle "+name+" cannot be solid: "+err.Error())
+		return
+	}
+
+	for fixture := body.GetFixtureList(); fixture != nil; fixture = fixture.GetNext() {
+		fixture.SetUserData(solidOutline{})
+	}
+
+	for _, piece := range pieces {
+		if polygon.GetSignedArea(piece) < solidMinPieceArea {
+			continue
+		}
+
+		b2vertices := make([]box2d.B2Vec2, len(piece))
+		for i, vertex := range piece {
+			b2vertices[i] = vertex.ToB2Vec2()
+		}
+
+		shape := box2d.MakeB2PolygonShape()
+		shape.Set(b2vertices, len(b2vertices))
+		body.CreateFixture(&shape, 0.0).SetRestitution(deathmatch.physics.wallRestitution)
+	}
+}
+
+// origin: position of the body; vertices are relative to it
+func newEntityGroundOrObstacle(deathmatch *DeathmatchGame, polygon mapcontainer.MapPolygon, obstacletype string, name string, bodytype uint8, origin space.PhysicalVector2) *ecs.Entity {
 
 	obstacle := deathmatch.manager.NewEntity()
 
 	bodydef := box2d.MakeB2BodyDef()
-	bodydef.Type = box2d.B2BodyType.B2_staticBody
+	bodydef.Type = bodytype
+	bodydef.Position = origin.ToB2Vec2()
 
 	body := deathmatch.PhysicalWorld.CreateBody(&bodydef)
 	vertices := make([]box2d.B2Vec2, len(polygon.Points))
 
 	for i := 0; i < len(polygon.Points); i++ {
-		vertices[i].Set(polygon.Points[i].GetX(), polygon.Points[i].GetY()*-1) // TODO(jerome): invert axes in transform, not here
+		vertices[i] = deathmatch.spaces.MapToPhysical(polygon.Points[i].ToMapVector2()).Vector2().Sub(origin.Vector2()).ToB2Vec2()
 	}
 
 	defer func() {
@@ -57,7 +258,7 @@ func newEntityGroundOrObstacle(deathmatch *DeathmatchGame, polygon mapcontainer.
 	for cur := 0; cur < len(vertices); cur++ {
 		shape := box2d.MakeB2EdgeShape()
 		shape.Set(vertices[prev], vertices[cur])
-		body.CreateFixture(&shape, 0.0)
+		body.CreateFixture(&shape, 0.0).SetRestitution(deathmatch.physics.wallRestitution)
 
 		prev = cur
 	}
@@ -70,6 +271,11 @@ func newEntityGroundOrObstacle(deathmatch *DeathmatchGame, polygon mapcontainer.
 	return obstacle.
 		AddComponent(deathmatch.physicalBodyComponent, &PhysicalBody{
 			body:   body,
-			static: true,
+			static: true, // never steered; kinematic ones are driven by systemMotion
+
+			spaces: deathmatch.spaces,
+
+			timeScaleIn:  float64(deathmatch.gameDescription.GetTps()),
+			timeScaleOut: 1.0 / float64(deathmatch.gameDescription.GetTps()),
 		})
 }
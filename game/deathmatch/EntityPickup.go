@@ -0,0 +1,168 @@
+package deathmatch
+
+import (
+	"math"
+	"strings"
+
+	"github.com/bytearena/box2d"
+	"github.com/bytearena/ecs"
+
+	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/common/utils"
+	"github.com/bytearena/core/common/utils/vector"
+	"github.com/bytearena/core/game/deathmatch/events"
+)
+
+const pickupTagPrefix = "pickup:"
+
+// Returns the kind of pickup described by the tags of a map point ("" if none)
+func getPickupKindFromTags(tags []string) string {
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, pickupTagPrefix) {
+			continue
+		}
+
+		switch kind := strings.TrimPrefix(tag, pickupTagPrefix); kind {
+		case pickupKind.Health, pickupKind.Energy, pickupKind.Speed:
+			return kind
+		}
+	}
+
+	return ""
+}
+
+func makePickup(kind string) *Pickup {
+	pickup := &Pickup{
+		kind:         kind,
+		respawnDelay: 300,
+		available:    true,
+	}
+
+	switch kind {
+	case pickupKind.Health:
+		pickup.amount = 400 // life restored
+	case pickupKind.Energy:
+		pickup.amount = 500 // shoot energy restored
+	case pickupKind.Speed:
+		pickup.amount = 1.5 // max speed factor
+		pickup.duration = 100
+	}
+
+	return pickup
+}
+
+func (deathmatch *DeathmatchGame) NewEntityPickup(point mapcontainer.MapPoint, kind string) *ecs.Entity {
+
+	pickup := deathmatch.manager.NewEntity()
+
+	bodyRadius := 1.0 // meters
+
+	bodydef := box2d.MakeB2BodyDef()
+	bodydef.Type = box2d.B2BodyType.B2_staticBody
+	bodydef.Position = deathmatch.spaces.MapToPhysical(point.ToMapVector2()).ToB2Vec2()
+
+	body := deathmatch.PhysicalWorld.CreateBody(&bodydef)
+	body.SetUserData(commontypes.MakePhysicalBodyDescriptor(
+		commontypes.PhysicalBodyDescriptorType.Pickup,
+		pickup.GetID(),
+	))
+
+	shape := box2d.MakeB2CircleShape()
+	shape.SetRadius(deathmatch.spaces.AgentToPhysicalDistance(bodyRadius))
+
+	fixturedef := box2d.MakeB2FixtureDef()
+	fixturedef.Shape = &shape
+	fixturedef.IsSensor = true
+
+	body.CreateFixtureFromDef(&fixturedef)
+
+	return pickup.
+		AddComponent(deathmatch.physicalBodyComponent, &PhysicalBody{
+			body:   body,
+			static: true,
+
+			spaces: deathmatch.spaces,
+		}).
+		AddComponent(deathmatch.renderComponent, &Render{
+			type_:  "pickup:" + kind,
+			static: true,
+		}).
+		AddComponent(deathmatch.pickupComponent, makePickup(kind)).
+		AddComponent(deathmatch.collidableComponent, &Collidable{
+			collisiongroup: CollisionGroup.Sensor,
+			collideswith: utils.BuildTag(
+				CollisionGroup.Agent,
+			),
+			isSensor:            true,
+			collisionScriptFunc: pickupCollisionScript,
+		})
+}
+
+func pickupCollisionScript(game *DeathmatchGame, entityID ecs.EntityID, otherEntityID ecs.EntityID, collidableAspect *Collidable, otherCollidableAspectB *Collidable, point vector.Vector2) {
+	game.collectPickup(otherEntityID, entityID)
+}
+
+func (deathmatch *DeathmatchGame) collectPickup(collectorID ecs.EntityID, pickupID ecs.EntityID) {
+
+	pickupQr := deathmatch.getEntity(pickupID, deathmatch.pickupComponent, deathmatch.physicalBodyComponent)
+	if pickupQr == nil {
+		return
+	}
+
+	pickupAspect := pickupQr.Components[deathmatch.pickupComponent].(*Pickup)
+	if !pickupAspect.available {
+		return
+	}
+
+	collectorQr := deathmatch.getEntity(collectorID,
+		deathmatch.healthComponent,
+		deathmatch.shootingComponent,
+		deathmatch.physicalBodyComponent,
+		deathmatch.lifecycleComponent,
+	)
+	if collectorQr == nil {
+		// only agents collect pickups
+		return
+	}
+
+	lifecycleAspect := collectorQr.Components[deathmatch.lifecycleComponent].(*Lifecycle)
+	if lifecycleAspect.locked {
+		// respawning agents do not collect pickups
+		return
+	}
+
+	switch pickupAspect.kind {
+	case pickupKind.Health:
+		healthAspect := collectorQr.Components[deathmatch.healthComponent].(*Health)
+		healthAspect.AddLife(pickupAspect.amount)
+	case pickupKind.Energy:
+		shootingAspect := collectorQr.Components[deathmatch.shootingComponent].(*Shooting)
+		shootingAspect.ShootEnergy = math.Min(shootingAspect.ShootEnergy+pickupAspect.amount, shootingAspect.MaxShootEnergy)
+	case pickupKind.Speed:
+		physicalAspect := collectorQr.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+		boostQr := deathmatch.getEntity(collectorID, deathmatch.speedBoostComponent)
+		if boostQr != nil {
+			// already boosted; extending the boost
+			boostQr.Components[deathmatch.speedBoostComponent].(*SpeedBoost).until = deathmatch.ticknum + pickupAspect.duration
+		} else {
+			collectorQr.Entity.AddComponent(deathmatch.speedBoostComponent, &SpeedBoost{
+				baseMaxSpeed: physicalAspect.GetMaxSpeed(),
+				until:        deathmatch.ticknum + pickupAspect.duration,
+			})
+			physicalAspect.SetMaxSpeed(physicalAspect.GetMaxSpeed() * pickupAspect.amount)
+		}
+	}
+
+	pickupAspect.available = false
+	pickupAspect.respawnAt = deathmatch.ticknum + pickupAspect.respawnDelay
+
+	physicalAspect := pickupQr.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+	physicalAspect.GetBody().SetActive(false)
+
+	deathmatch.BusPublish(events.EntityCollectedPickup{
+		Entity: collectorID,
+		Pickup: pickupID,
+		Kind:   pickupAspect.kind,
+	})
+}
@@ -8,10 +8,11 @@ import (
 
 	"github.com/bytearena/core/common/types"
 	"github.com/bytearena/core/common/utils"
+	"github.com/bytearena/core/common/utils/space"
 	"github.com/bytearena/core/common/utils/vector"
 )
 
-func (deathmatch *DeathmatchGame) NewEntityBallisticProjectile(ownerid ecs.EntityID, position vector.Vector2, velocity vector.Vector2) *ecs.Entity {
+func (deathmatch *DeathmatchGame) NewEntityBallisticProjectile(ownerid ecs.EntityID, position space.AgentVector2, velocity vector.Vector2, weapon *Weapon) *ecs.Entity {
 
 	ownerAspects := deathmatch.getEntity(ownerid,
 		deathmatch.shootingComponent,
@@ -29,12 +30,10 @@ func (deathmatch *DeathmatchGame) NewEntityBallisticProjectile(ownerid ecs.Entit
 	timeScaleOut := 1 / timeScaleIn
 	///////////////////////////////////////////////////////////////////////////
 
-	shootingAspect := ownerAspects.Components[deathmatch.shootingComponent].(*Shooting)
-
-	bodyRadius := 0.3                                   // meters
-	projectilespeed := shootingAspect.ProjectileSpeed   // m/tick
-	projectiledamage := shootingAspect.ProjectileDamage // amount of life consumed on impact
-	projectilerange := shootingAspect.ProjectileRange   // in meter
+	bodyRadius := weapon.ProjectileRadius       // meters
+	projectilespeed := weapon.ProjectileSpeed   // m/tick
+	projectiledamage := weapon.ProjectileDamage // amount of life consumed on impact
+	projectilerange := weapon.ProjectileRange   // in meter
 
 	projectilettl := 0
 
@@ -49,15 +48,15 @@ func (deathmatch *DeathmatchGame) NewEntityBallisticProjectile(ownerid ecs.Entit
 	bodydef.AllowSleep = true
 	bodydef.FixedRotation = true
 
-	physicalReferentialPosition := position.Transform(deathmatch.physicalToAgentSpaceInverseTransform)
-	bodydef.Position.Set(physicalReferentialPosition.GetX(), physicalReferentialPosition.GetY())
+	bodydef.Position = deathmatch.spaces.AgentToPhysical(position).ToB2Vec2()
 
-	physicalReferentialVelocity := velocity.
-		SetMag(projectilespeed).
-		Scale(timeScaleIn).
-		Transform(deathmatch.physicalToAgentSpaceInverseTransform)
+	physicalReferentialVelocity := deathmatch.spaces.AgentToPhysicalDirection(space.MakeAgentVector2FromVector2(
+		velocity.
+			SetMag(projectilespeed).
+			Scale(timeScaleIn),
+	))
 
-	bodydef.LinearVelocity = box2d.MakeB2Vec2(physicalReferentialVelocity.GetX(), physicalReferentialVelocity.GetY())
+	bodydef.LinearVelocity = physicalReferentialVelocity.ToB2Vec2()
 
 	body := deathmatch.PhysicalWorld.CreateBody(&bodydef)
 	body.SetBullet(true)
@@ -68,11 +67,11 @@ func (deathmatch *DeathmatchGame) NewEntityBallisticProjectile(ownerid ecs.Entit
 	))
 
 	shape := box2d.MakeB2CircleShape()
-	shape.SetRadius(bodyRadius * deathmatch.physicalToAgentSpaceInverseScale)
+	shape.SetRadius(deathmatch.spaces.AgentToPhysicalDistance(bodyRadius))
 
 	fixturedef := box2d.MakeB2FixtureDef()
 	fixturedef.Shape = &shape
-	fixturedef.Density = 20.0
+	fixturedef.Density = physicsDefaultDensity
 
 	body.CreateFixtureFromDef(&fixturedef)
 
@@ -83,11 +82,7 @@ func (deathmatch *DeathmatchGame) NewEntityBallisticProjectile(ownerid ecs.Entit
 			maxAngularVelocity: 0,
 			dragForce:          0,
 
-			pointTransformIn:  deathmatch.physicalToAgentSpaceInverseTransform,
-			pointTransformOut: deathmatch.physicalToAgentSpaceTransform,
-
-			distanceScaleIn:  deathmatch.physicalToAgentSpaceInverseScale, // same as transform matrix, but scale only (for 1D transforms of length)
-			distanceScaleOut: deathmatch.physicalToAgentSpaceScale,        // same as transform matrix, but scale only (for 1D transforms of length)
+			spaces: deathmatch.spaces,
 
 			timeScaleIn:  timeScaleIn,  // m/tick to m/s; => ticksPerSecond
 			timeScaleOut: timeScaleOut, // m/s to m/tick; => 1 / ticksPerSecond
@@ -103,7 +98,9 @@ func (deathmatch *DeathmatchGame) NewEntityBallisticProjectile(ownerid ecs.Entit
 		}).
 		AddComponent(deathmatch.ownedComponent, &Owned{ownerid}).
 		AddComponent(deathmatch.impactorComponent, &Impactor{
-			damage: projectiledamage,
+			damage:       projectiledamage,
+			splashRadius: weapon.SplashRadius,
+			splashDamage: weapon.SplashDamage,
 		}).
 		AddComponent(deathmatch.collidableComponent, &Collidable{
 			collisiongroup: CollisionGroup.Projectile,
@@ -127,7 +124,26 @@ func projectileCollisionScript(game *DeathmatchGame, entityID ecs.EntityID, othe
 
 	physicalAspect.
 		SetVelocity(vector.MakeNullVector2()).
-		SetPosition(point)
+		SetPosition(space.MakeAgentVector2FromVector2(point))
 
 	lifecycleAspect.SetDeath(game.ticknum) // dead in this tick
+
+	impactorResult := game.getEntity(entityID, game.impactorComponent, game.ownedComponent)
+	if impactorResult == nil {
+		return
+	}
+
+	impactorAspect := impactorResult.Components[game.impactorComponent].(*Impactor)
+	ownedAspect := impactorResult.Components[game.ownedComponent].(*Owned)
+
+	if impactorAspect.GetSplashRadius() > 0 {
+		game.explosions = append(game.explosions, explosion{
+			entityID:    entityID,
+			ownerID:     ownedAspect.GetOwner(),
+			directHitID: otherEntityID,
+			point:       point,
+			radius:      impactorAspect.GetSplashRadius(),
+			damage:      impactorAspect.GetSplashDamage(),
+		})
+	}
 }
@@ -28,7 +28,7 @@ func (deathmatch *DeathmatchGame) NewEntitySensor(
 	vertices := make([]box2d.B2Vec2, len(polygon.Points))
 
 	for i := 0; i < len(polygon.Points); i++ {
-		vertices[i].Set(polygon.Points[i].GetX(), polygon.Points[i].GetY()*-1) // TODO(jerome): invert axes in transform, not here
+		vertices[i] = deathmatch.spaces.MapToPhysical(polygon.Points[i].ToMapVector2()).ToB2Vec2()
 	}
 
 	defer func() {
@@ -0,0 +1,346 @@
+package deathmatch
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/common/utils"
+	"github.com/bytearena/core/common/utils/trigo"
+	"github.com/bytearena/core/common/utils/vector"
+	"github.com/bytearena/core/game/deathmatch/events"
+	"github.com/bytearena/core/game/deathmatch/mailboxmessages"
+)
+
+const ctfFlagTagPrefix = "ctf:flag:" // point objects; ctf:flag:<team>
+const ctfBaseTagPrefix = "ctf:base:" // polygon objects; ctf:base:<team>
+const ctfDefaultCaptureLimit = 3
+
+type ctfState struct {
+	flags        map[string]ecs.EntityID       // by team
+	bases        map[string][][]vector.Vector2 // by team; physical referential
+	scores       map[string]int                // captures, by team
+	returned     map[string]bool               // flags back home during the tick, by team
+	captureLimit int
+}
+
+// Returns the team named by the first tag having the given prefix ("" if none)
+func getTeamFromTags(tags []string, prefix string) string {
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, prefix) {
+			return strings.TrimPrefix(tag, prefix)
+		}
+	}
+
+	return ""
+}
+
+func initCTF(deathmatch *DeathmatchGame, arenaMap *mapcontainer.MapContainer) {
+
+	state := &ctfState{
+		flags:        make(map[string]ecs.EntityID),
+		bases:        make(map[string][][]vector.Vector2),
+		scores:       make(map[string]int),
+		returned:     make(map[string]bool),
+		captureLimit: ctfDefaultCaptureLimit,
+	}
+
+	if limit, ok := arenaMap.Meta.Options["capturelimit"].(float64); ok && limit > 0 {
+		state.captureLimit = int(limit)
+	}
+
+	deathmatch.ctf = state
+
+	// Flags
+	for _, otherObject := range arenaMap.Data.OtherPointObjects {
+		team := getTeamFromTags(otherObject.Tags, ctfFlagTagPrefix)
+		if team == "" {
+			continue
+		}
+
+		if _, exists := state.flags[team]; exists {
+			// one flag per team
+			continue
+		}
+
+		state.flags[team] = deathmatch.NewEntityFlag(otherObject.Point, team).GetID()
+		state.scores[team] = 0
+		deathmatch.teams = append(deathmatch.teams, team)
+	}
+
+	sort.Strings(deathmatch.teams)
+
+	// Capture sensors
+	for _, otherObject := range arenaMap.Data.OtherPolygonObjects {
+		team := getTeamFromTags(otherObject.Tags, ctfBaseTagPrefix)
+		if team == "" {
+			continue
+		}
+
+		base := make([]vector.Vector2, 0)
+		for _, point := range otherObject.Polygon.Points {
+			base = append(base, deathmatch.spaces.MapToPhysical(point.ToMapVector2()).Vector2())
+		}
+
+		state.bases[team] = append(state.bases[team], base)
+
+		deathmatch.NewEntitySensor(
+			otherObject.Polygon,
+			otherObject.Name,
+			func(entityid ecs.EntityID, sensorid ecs.EntityID) {
+				deathmatch.enterBase(entityid, team)
+			},
+			utils.BuildTag(
+				CollisionGroup.Agent,
+			),
+		)
+	}
+}
+
+func (deathmatch *DeathmatchGame) getFlag(team string) (ecs.EntityID, *Flag) {
+	flagID, ok := deathmatch.ctf.flags[team]
+	if !ok {
+		return 0, nil
+	}
+
+	qr := deathmatch.getEntity(flagID, deathmatch.flagComponent)
+	if qr == nil {
+		return 0, nil
+	}
+
+	return flagID, qr.Components[deathmatch.flagComponent].(*Flag)
+}
+
+// Returns the flag carried by the entity, if any
+func (deathmatch *DeathmatchGame) getCarriedFlag(carrierID ecs.EntityID) (ecs.EntityID, *Flag) {
+	for _, team := range deathmatch.teams {
+		flagID, flagAspect := deathmatch.getFlag(team)
+		if flagAspect == nil {
+			continue
+		}
+
+		if carrier, carried := flagAspect.GetCarrier(); carried && carrier == carrierID {
+			return flagID, flagAspect
+		}
+	}
+
+	return 0, nil
+}
+
+func (deathmatch *DeathmatchGame) getTeamOf(entityID ecs.EntityID) (string, bool) {
+	qr := deathmatch.getEntity(entityID, deathmatch.teamComponent, deathmatch.lifecycleComponent)
+	if qr == nil {
+		return "", false
+	}
+
+	if qr.Components[deathmatch.lifecycleComponent].(*Lifecycle).locked {
+		// respawning agents do not interact with flags
+		return "", false
+	}
+
+	return qr.Components[deathmatch.teamComponent].(*Team).GetName(), true
+}
+
+func (deathmatch *DeathmatchGame) touchFlag(agentID ecs.EntityID, flagID ecs.EntityID) {
+
+	team, ok := deathmatch.getTeamOf(agentID)
+	if !ok {
+		return
+	}
+
+	qr := deathmatch.getEntity(flagID, deathmatch.flagComponent)
+	if qr == nil {
+		return
+	}
+
+	flagAspect := qr.Components[deathmatch.flagComponent].(*Flag)
+	if flagAspect.carried {
+		return
+	}
+
+	if flagAspect.team == team {
+		// flags are never dropped (return-on-death): a flag that is not carried is at home
+		return
+	}
+
+	if _, carried := deathmatch.getCarriedFlag(agentID); carried != nil {
+		// one flag at a time
+		return
+	}
+
+	flagAspect.carried = true
+	flagAspect.carrier = agentID
+	qr.Entity.AddComponent(deathmatch.ownedComponent, &Owned{agentID})
+
+	deathmatch.BusPublish(events.FlagTaken{
+		Flag: flagID,
+		Team: flagAspect.team,
+		By:   agentID,
+	})
+}
+
+func (deathmatch *DeathmatchGame) returnFlag(flagID ecs.EntityID) {
+
+	flagAspect := deathmatch.resetFlag(flagID)
+	if flagAspect == nil {
+		return
+	}
+
+	deathmatch.BusPublish(events.FlagReturned{
+		Flag: flagID,
+		Team: flagAspect.team,
+	})
+}
+
+// Puts the flag back home
+func (deathmatch *DeathmatchGame) resetFlag(flagID ecs.EntityID) *Flag {
+
+	qr := deathmatch.getEntity(flagID, deathmatch.flagComponent, deathmatch.physicalBodyComponent)
+	if qr == nil {
+		return nil
+	}
+
+	flagAspect := qr.Components[deathmatch.flagComponent].(*Flag)
+	physicalAspect := qr.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+
+	if flagAspect.carried {
+		qr.Entity.RemoveComponent(deathmatch.ownedComponent)
+		deathmatch.ctf.returned[flagAspect.team] = true
+	}
+
+	flagAspect.carried = false
+	physicalAspect.SetPositionInPhysicalScale(flagAspect.home)
+
+	return flagAspect
+}
+
+func (deathmatch *DeathmatchGame) enterBase(agentID ecs.EntityID, baseTeam string) {
+
+	team, ok := deathmatch.getTeamOf(agentID)
+	if !ok || team != baseTeam {
+		return
+	}
+
+	carriedFlagID, carriedFlag := deathmatch.getCarriedFlag(agentID)
+	if carriedFlag == nil {
+		return
+	}
+
+	if _, ownFlag := deathmatch.getFlag(team); ownFlag != nil && ownFlag.carried {
+		// cannot capture while the own flag is away
+		return
+	}
+
+	capturedTeam := carriedFlag.team
+	deathmatch.resetFlag(carriedFlagID)
+
+	deathmatch.ctf.scores[team]++
+
+	deathmatch.BusPublish(events.FlagCaptured{
+		Flag: carriedFlagID,
+		Team: capturedTeam,
+		By:   agentID,
+	})
+
+	if deathmatch.ctf.scores[team] >= deathmatch.ctf.captureLimit {
+		deathmatch.endMatch(matchEndReason.Objective, team)
+	}
+}
+
+// Carried flags follow their carrier; flags of vanished carriers go home
+func systemCTF(deathmatch *DeathmatchGame) {
+
+	if deathmatch.ctf == nil {
+		return
+	}
+
+	for _, team := range deathmatch.teams {
+		flagID, flagAspect := deathmatch.getFlag(team)
+		if flagAspect == nil || !flagAspect.carried {
+			continue
+		}
+
+		carrierQr := deathmatch.getEntity(flagAspect.carrier, deathmatch.physicalBodyComponent)
+		if carrierQr == nil {
+			deathmatch.returnFlag(flagID)
+			continue
+		}
+
+		flagQr := deathmatch.getEntity(flagID, deathmatch.physicalBodyComponent)
+		carrierPhysicalAspect := carrierQr.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+		flagPhysicalAspect := flagQr.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+
+		flagPhysicalAspect.SetPositionInPhysicalScale(carrierPhysicalAspect.GetPhysicalReferentialPosition())
+	}
+
+	// The base sensors only report carriers entering the base; carriers waiting inside
+	// for their own flag to come home capture as soon as it does
+	for _, team := range deathmatch.teams {
+		if !deathmatch.ctf.returned[team] {
+			continue
+		}
+
+		delete(deathmatch.ctf.returned, team)
+		deathmatch.captureFromInsideBase(team)
+	}
+}
+
+func (deathmatch *DeathmatchGame) captureFromInsideBase(team string) {
+	for _, flagTeam := range deathmatch.teams {
+		_, flagAspect := deathmatch.getFlag(flagTeam)
+		if flagAspect == nil || !flagAspect.carried {
+			continue
+		}
+
+		carrierTeam, ok := deathmatch.getTeamOf(flagAspect.carrier)
+		if !ok || carrierTeam != team {
+			continue
+		}
+
+		carrierQr := deathmatch.getEntity(flagAspect.carrier, deathmatch.physicalBodyComponent)
+		if carrierQr == nil {
+			continue
+		}
+
+		position := carrierQr.Components[deathmatch.physicalBodyComponent].(*PhysicalBody).GetPhysicalReferentialPosition().Vector2()
+		for _, base := range deathmatch.ctf.bases[team] {
+			if trigo.PointIsInPolygon(position, base) {
+				deathmatch.enterBase(flagAspect.carrier, team)
+				break
+			}
+		}
+	}
+}
+
+func (game *DeathmatchGame) onEntityFraggedCTF(e events.EntityFragged) {
+	// Return-on-death: the flag of a fragged carrier goes home
+	// Not publishing FlagReturned here: the bus is locked while handling this event
+	if flagID, flagAspect := game.getCarriedFlag(e.Entity); flagAspect != nil {
+		game.resetFlag(flagID)
+		game.broadcastMessage(mailboxmessages.FlagReturned{
+			Team: flagAspect.team,
+		})
+	}
+}
+
+func (game *DeathmatchGame) onFlagTaken(e events.FlagTaken) {
+	game.broadcastMessage(mailboxmessages.FlagTaken{
+		Team: e.Team,
+		By:   e.By.String(),
+	})
+}
+
+func (game *DeathmatchGame) onFlagReturned(e events.FlagReturned) {
+	game.broadcastMessage(mailboxmessages.FlagReturned{
+		Team: e.Team,
+	})
+}
+
+func (game *DeathmatchGame) onFlagCaptured(e events.FlagCaptured) {
+	game.broadcastMessage(mailboxmessages.FlagCaptured{
+		Team: e.Team,
+		By:   e.By.String(),
+	})
+}
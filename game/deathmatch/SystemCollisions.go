@@ -5,6 +5,7 @@ import (
 	"github.com/bytearena/ecs"
 
 	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/utils/space"
 	"github.com/bytearena/core/common/utils/vector"
 )
 
@@ -16,8 +17,8 @@ type collision struct {
 	point             vector.Vector2
 	collisionAngleA   float64
 	collisionAngleB   float64
+	toi               float64 // fraction of the tick; 1 for Box2D contacts, which are reported after the step
 	// normal            vector.Vector2
-	// toi               float64
 	// friction          float64
 	// restitution       float64
 }
@@ -65,11 +66,11 @@ func systemCollisions(deathmatch *DeathmatchGame) []collision {
 			entityIDB:         B.ID,
 			collidableAspectA: collidableAspectA,
 			collidableAspectB: collidableAspectB,
-			point:             vector.FromB2Vec2(worldManifold.Points[0]).Transform(deathmatch.physicalToAgentSpaceTransform),
+			point:             deathmatch.spaces.PhysicalToAgent(space.PhysicalFromB2Vec2(worldManifold.Points[0])).Vector2(),
 			collisionAngleA:   collisionAngleA,
 			collisionAngleB:   collisionAngleB,
-			//normal:            vector.FromB2Vec2(worldManifold.Normal).Transform(deathmatch.physicalToAgentSpaceTransform),
-			// toi:               coll.GetTOI(),
+			toi:               1,
+			//normal:            deathmatch.spaces.PhysicalToAgentDirection(space.PhysicalFromB2Vec2(worldManifold.Normal)).Vector2(),
 			// friction:          coll.GetFriction(),
 			// restitution:       coll.GetRestitution(),
 		}
@@ -80,5 +81,42 @@ func systemCollisions(deathmatch *DeathmatchGame) []collision {
 		collidableAspectB.CollisionScript(deathmatch, B.ID, A.ID, collidableAspectB, collidableAspectA, compiledCollision.point)
 	}
 
+	// Swept projectile hits, in time order
+	for _, hit := range deathmatch.projectileHits {
+		entityResultProjectile := deathmatch.getEntity(hit.projectileID, deathmatch.collidableComponent, deathmatch.physicalBodyComponent, deathmatch.lifecycleComponent)
+		entityResultTarget := deathmatch.getEntity(hit.targetID, deathmatch.collidableComponent, deathmatch.physicalBodyComponent)
+
+		if entityResultProjectile == nil || entityResultTarget == nil {
+			continue
+		}
+
+		if entityResultProjectile.Components[deathmatch.lifecycleComponent].(*Lifecycle).GetDeath() > 0 {
+			// stopped by another projectile during the step
+			continue
+		}
+
+		collidableAspectProjectile := entityResultProjectile.Components[deathmatch.collidableComponent].(*Collidable)
+		collidableAspectTarget := entityResultTarget.Components[deathmatch.collidableComponent].(*Collidable)
+
+		velProjectile := entityResultProjectile.Components[deathmatch.physicalBodyComponent].(*PhysicalBody).GetVelocity()
+		velTarget := entityResultTarget.Components[deathmatch.physicalBodyComponent].(*PhysicalBody).GetVelocity()
+
+		compiledCollision := collision{
+			entityIDA:         hit.projectileID,
+			entityIDB:         hit.targetID,
+			collidableAspectA: collidableAspectProjectile,
+			collidableAspectB: collidableAspectTarget,
+			point:             hit.point,
+			collisionAngleA:   velTarget.Sub(velProjectile).Angle(),
+			collisionAngleB:   velProjectile.Sub(velTarget).Angle(),
+			toi:               hit.toi,
+		}
+
+		collisions = append(collisions, compiledCollision)
+
+		collidableAspectProjectile.CollisionScript(deathmatch, hit.projectileID, hit.targetID, collidableAspectProjectile, collidableAspectTarget, hit.point)
+		collidableAspectTarget.CollisionScript(deathmatch, hit.targetID, hit.projectileID, collidableAspectTarget, collidableAspectProjectile, hit.point)
+	}
+
 	return collisions
 }
@@ -1,11 +1,19 @@
 package deathmatch
 
 import (
+	"math"
+
+	"github.com/bytearena/box2d"
 	"github.com/bytearena/ecs"
 
+	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/utils/space"
+	"github.com/bytearena/core/common/utils/vector"
 	"github.com/bytearena/core/game/deathmatch/events"
 )
 
+const explosionOcclusionTolerance = 0.01 // in m; the surface the projectile exploded on does not shield
+
 type killedType struct {
 	Entity   ecs.EntityID
 	KilledBy ecs.EntityID
@@ -14,6 +22,7 @@ type killedType struct {
 func systemHealth(deathmatch *DeathmatchGame, collisions []collision) {
 
 	killed := make([]killedType, 0)
+	deathmatch.sounds = make([]sound, 0)
 
 	for _, coll := range collisions {
 
@@ -24,13 +33,32 @@ func systemHealth(deathmatch *DeathmatchGame, collisions []collision) {
 		entityResultBHealth := deathmatch.getEntity(coll.entityIDB, deathmatch.healthComponent)
 
 		if entityResultAHealth != nil && entityResultBImpactor != nil {
-			impactIfPossible(deathmatch, coll.entityIDA, entityResultAHealth, entityResultBImpactor, coll.collisionAngleA, &killed)
+			impactorAspect := entityResultBImpactor.Components[deathmatch.impactorComponent].(*Impactor)
+			impactIfPossible(deathmatch, coll.entityIDA, entityResultAHealth, coll.entityIDB, impactorAspect.GetDamage(), coll.collisionAngleA, &killed)
 		}
 
 		if entityResultBHealth != nil && entityResultAImpactor != nil {
-			impactIfPossible(deathmatch, coll.entityIDB, entityResultBHealth, entityResultAImpactor, coll.collisionAngleB, &killed)
+			impactorAspect := entityResultAImpactor.Components[deathmatch.impactorComponent].(*Impactor)
+			impactIfPossible(deathmatch, coll.entityIDB, entityResultBHealth, coll.entityIDA, impactorAspect.GetDamage(), coll.collisionAngleB, &killed)
+		}
+	}
+
+	// Explosions are turned into impacts on every entity in their radius
+	for _, expl := range deathmatch.explosions {
+		deathmatch.impacts = append(deathmatch.impacts, computeExplosionImpacts(deathmatch, expl)...)
+	}
+	deathmatch.explosions = make([]explosion, 0)
+
+	// Impacts that do not come from collisions (hitscan, splash)
+	for _, imp := range deathmatch.impacts {
+		entityResultHealth := deathmatch.getEntity(imp.entityID, deathmatch.healthComponent)
+		if entityResultHealth == nil {
+			continue
 		}
+
+		impactIfPossible(deathmatch, imp.entityID, entityResultHealth, imp.impactorID, imp.damage, imp.comingFrom, &killed)
 	}
+	deathmatch.impacts = make([]impact, 0)
 
 	for _, kill := range killed {
 		lifecycleQr := deathmatch.getEntity(kill.Entity, deathmatch.lifecycleComponent)
@@ -41,6 +69,15 @@ func systemHealth(deathmatch *DeathmatchGame, collisions []collision) {
 		lifecycleAspect := lifecycleQr.Components[deathmatch.lifecycleComponent].(*Lifecycle)
 		lifecycleAspect.SetDeath(deathmatch.ticknum)
 
+		if deathmatch.getEntity(kill.Entity, deathmatch.playerComponent) == nil {
+			// Not an agent (destructible obstacle): destroyed, not fragged
+			deathmatch.BusPublish(events.EntityDestroyed{
+				Entity:      kill.Entity,
+				DestroyedBy: kill.KilledBy,
+			})
+			continue
+		}
+
 		// Publish Frag event
 		deathmatch.BusPublish(events.EntityFragged{
 			Entity:    kill.Entity,
@@ -49,13 +86,112 @@ func systemHealth(deathmatch *DeathmatchGame, collisions []collision) {
 	}
 }
 
-func impactIfPossible(deathmatch *DeathmatchGame, impacteeID ecs.EntityID, impacteeHealth *ecs.QueryResult, impactor *ecs.QueryResult, collisionAngle float64, killed *[]killedType) {
+func computeExplosionImpacts(deathmatch *DeathmatchGame, expl explosion) []impact {
+
+	impacts := make([]impact, 0)
+
+	aabb := vector.GetAABBForPointList(
+		expl.point.Sub(vector.MakeVector2(expl.radius, expl.radius)),
+		expl.point.Add(vector.MakeVector2(expl.radius, expl.radius)),
+	)
+
+	inRadius := make(map[ecs.EntityID]bool)
+	deathmatch.PhysicalWorld.QueryAABB(func(fixture *box2d.B2Fixture) bool {
+		if descriptor, ok := fixture.GetBody().GetUserData().(commontypes.PhysicalBodyDescriptor); ok {
+			if descriptor.Type == commontypes.PhysicalBodyDescriptorType.Agent {
+				inRadius[descriptor.ID] = true
+			}
+		}
+		return true // keep going to find all fixtures in the query area
+	}, vector.GetAABBForPointList(
+		deathmatch.spaces.AgentToPhysical(space.MakeAgentVector2FromVector2(aabb.LowerBound)).Vector2(),
+		deathmatch.spaces.AgentToPhysical(space.MakeAgentVector2FromVector2(aabb.UpperBound)).Vector2(),
+	).ToB2AABB())
+
+	for entityID := range inRadius {
+
+		if entityID == expl.ownerID {
+			// no self-damage
+			continue
+		}
+
+		if entityID == expl.directHitID {
+			// already damaged by the projectile itself
+			continue
+		}
+
+		qr := deathmatch.getEntity(entityID, deathmatch.physicalBodyComponent)
+		if qr == nil {
+			continue
+		}
+
+		physicalAspect := qr.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+		centerToEntity := physicalAspect.GetPosition().Sub(expl.point)
+
+		distance := math.Max(0, centerToEntity.Mag()-physicalAspect.GetRadius())
+		if distance >= expl.radius {
+			continue
+		}
+
+		if isExplosionOccluded(deathmatch, expl.point, physicalAspect.GetPosition()) {
+			// behind a wall
+			continue
+		}
+
+		impacts = append(impacts, impact{
+			entityID:   entityID,
+			impactorID: expl.entityID,
+			damage:     expl.damage * (1 - distance/expl.radius), // damage fades linearly with distance
+			comingFrom: centerToEntity.Angle(),
+		})
+	}
+
+	return impacts
+}
+
+// Obstacles between the explosion and the target shield it
+func isExplosionOccluded(deathmatch *DeathmatchGame, point vector.Vector2, target vector.Vector2) bool {
+
+	length := target.Sub(point).Mag()
+	if length <= explosionOcclusionTolerance {
+		return false
+	}
+
+	from := deathmatch.spaces.AgentToPhysical(space.MakeAgentVector2FromVector2(point))
+	to := deathmatch.spaces.AgentToPhysical(space.MakeAgentVector2FromVector2(target))
+
+	occluded := false
+
+	deathmatch.PhysicalWorld.RayCast(func(fixture *box2d.B2Fixture, point box2d.B2Vec2, normal box2d.B2Vec2, fraction float64) float64 {
+
+		if fixture.IsSensor() {
+			return -1 // ignore this fixture
+		}
+
+		descriptor, ok := fixture.GetBody().GetUserData().(commontypes.PhysicalBodyDescriptor)
+		if !ok || descriptor.Type != commontypes.PhysicalBodyDescriptorType.Obstacle {
+			return -1 // ignore this fixture
+		}
+
+		if fraction*length <= explosionOcclusionTolerance {
+			// the surface the projectile exploded on
+			return -1
+		}
+
+		occluded = true
+		return 0 // terminate the ray cast
+	}, from.ToB2Vec2(), to.ToB2Vec2())
+
+	return occluded
+}
+
+func impactIfPossible(deathmatch *DeathmatchGame, impacteeID ecs.EntityID, impacteeHealth *ecs.QueryResult, impactorID ecs.EntityID, damage float64, collisionAngle float64, killed *[]killedType) {
 
 	lifecycleQr := deathmatch.getEntity(impacteeID, deathmatch.lifecycleComponent)
 	if lifecycleQr == nil {
 
 		// no lifecycle on impactee; cannot be locked, impacting !
-		impactWithDamage(deathmatch, impacteeHealth, impactor, collisionAngle, killed)
+		impactWithDamage(deathmatch, impacteeHealth, impactorID, damage, collisionAngle, killed)
 	} else {
 
 		// There's a lifecycle on impactee; check if entity is locked
@@ -63,28 +199,30 @@ func impactIfPossible(deathmatch *DeathmatchGame, impacteeID ecs.EntityID, impac
 
 		if !lifecycleAspect.locked {
 			// impactee not be locked, impacting !
-			impactWithDamage(deathmatch, impacteeHealth, impactor, collisionAngle, killed)
+			impactWithDamage(deathmatch, impacteeHealth, impactorID, damage, collisionAngle, killed)
 		}
 	}
 }
 
-func impactWithDamage(deathmatch *DeathmatchGame, qrHealth *ecs.QueryResult, qrImpactor *ecs.QueryResult, collisionAngle float64, killed *[]killedType) {
+func impactWithDamage(deathmatch *DeathmatchGame, qrHealth *ecs.QueryResult, impactorID ecs.EntityID, damage float64, collisionAngle float64, killed *[]killedType) {
 
 	impactedID := qrHealth.Entity.GetID()
-	impactorID := qrImpactor.Entity.GetID()
 
 	healthAspect := qrHealth.Components[deathmatch.healthComponent].(*Health)
-	impactorAspect := qrImpactor.Components[deathmatch.impactorComponent].(*Impactor)
+	if healthAspect.GetLife() <= 0 {
+		// already fragged in this tick
+		return
+	}
 
 	// Publish Hit event
 	deathmatch.BusPublish(events.EntityHit{
 		Entity:     impactedID,
 		HitBy:      impactorID,
 		ComingFrom: collisionAngle,
-		Damage:     impactorAspect.damage,
+		Damage:     damage,
 	})
 
-	healthAspect.AddLife(-1 * impactorAspect.damage)
+	healthAspect.AddLife(-1 * damage)
 	if healthAspect.GetLife() <= 0 {
 		healthAspect.SetLife(0)
 		*killed = append(*killed, killedType{
@@ -0,0 +1,155 @@
+package deathmatch
+
+import (
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/common/utils"
+	"github.com/bytearena/core/common/utils/trigo"
+	"github.com/bytearena/core/common/utils/vector"
+	"github.com/bytearena/core/game/deathmatch/events"
+	"github.com/bytearena/core/game/deathmatch/mailboxmessages"
+)
+
+const kothZoneTag = "koth:zone"  // polygon object
+const kothDefaultScoreCap = 1000 // ticks of sole control
+
+type kothState struct {
+	polygon []vector.Vector2 // physical referential
+
+	occupants map[ecs.EntityID]bool
+
+	holder    string // team or agent holding the zone; empty if none
+	contested bool
+
+	scores   map[string]int // ticks of sole control, by team or agent
+	scoreCap int
+	over     bool
+}
+
+func initKOTH(deathmatch *DeathmatchGame, arenaMap *mapcontainer.MapContainer) {
+
+	state := &kothState{
+		polygon:   make([]vector.Vector2, 0),
+		occupants: make(map[ecs.EntityID]bool),
+		scores:    make(map[string]int),
+		scoreCap:  kothDefaultScoreCap,
+	}
+
+	if scoreCap, ok := arenaMap.Meta.Options["scorecap"].(float64); ok && scoreCap > 0 {
+		state.scoreCap = int(scoreCap)
+	}
+
+	// Teams are optional; without them, agents play on their own
+	if teams, ok := arenaMap.Meta.Options["teams"].([]interface{}); ok {
+		for _, team := range teams {
+			if name, ok := team.(string); ok && name != "" {
+				deathmatch.teams = append(deathmatch.teams, name)
+			}
+		}
+	}
+
+	deathmatch.koth = state
+
+	for _, otherObject := range arenaMap.Data.OtherPolygonObjects {
+		if !utils.IsStringInArray(otherObject.Tags, kothZoneTag) {
+			continue
+		}
+
+		for _, point := range otherObject.Polygon.Points {
+			state.polygon = append(state.polygon, deathmatch.spaces.MapToPhysical(point.ToMapVector2()).Vector2())
+		}
+
+		// No sensor: occupants are found by position on every tick, as agents spawned inside,
+		// or crossing an edge between two steps, do not touch the edges
+
+		// one zone per map
+		break
+	}
+}
+
+// Agents in teams hold the zone for their team
+func (deathmatch *DeathmatchGame) getZoneHolderName(entityID ecs.EntityID) string {
+	if teamQr := deathmatch.getEntity(entityID, deathmatch.teamComponent); teamQr != nil {
+		return teamQr.Components[deathmatch.teamComponent].(*Team).GetName()
+	}
+
+	return entityID.String()
+}
+
+func systemKOTH(deathmatch *DeathmatchGame) {
+
+	state := deathmatch.koth
+	if state == nil || len(state.polygon) == 0 {
+		return
+	}
+
+	///////////////////////////////////////////////////////////////////////////
+	// Qui est dans la zone ?
+	///////////////////////////////////////////////////////////////////////////
+
+	// one zone: testing every agent is cheap
+	occupants := make(map[ecs.EntityID]bool)
+
+	for _, result := range deathmatch.agentsView.Get() {
+		id := result.Entity.GetID()
+
+		lifecycleQr := deathmatch.getEntity(id, deathmatch.lifecycleComponent)
+		if lifecycleQr == nil || lifecycleQr.Components[deathmatch.lifecycleComponent].(*Lifecycle).locked {
+			// respawning
+			continue
+		}
+
+		physicalAspect := result.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+		if trigo.PointIsInPolygon(physicalAspect.GetPhysicalReferentialPosition().Vector2(), state.polygon) {
+			occupants[id] = true
+		}
+	}
+
+	state.occupants = occupants
+
+	///////////////////////////////////////////////////////////////////////////
+	// Qui tient la zone ?
+	///////////////////////////////////////////////////////////////////////////
+
+	holders := make(map[string]bool)
+	for id := range state.occupants {
+		holders[deathmatch.getZoneHolderName(id)] = true
+	}
+
+	holder := ""
+	contested := len(holders) > 1
+	if len(holders) == 1 {
+		for name := range holders {
+			holder = name
+		}
+	}
+
+	if holder != state.holder || contested != state.contested {
+		state.holder = holder
+		state.contested = contested
+
+		deathmatch.BusPublish(events.ZoneControlChanged{
+			Holder:    holder,
+			Contested: contested,
+		})
+	}
+
+	if holder == "" {
+		return
+	}
+
+	state.scores[holder]++
+
+	if state.scores[holder] >= state.scoreCap && !state.over {
+		state.over = true
+		deathmatch.endMatch(matchEndReason.Objective, holder)
+	}
+}
+
+func (game *DeathmatchGame) onZoneControlChanged(e events.ZoneControlChanged) {
+	game.broadcastMessage(mailboxmessages.ZoneControlChanged{
+		Holder:    e.Holder,
+		Contested: e.Contested,
+	})
+}
@@ -0,0 +1,85 @@
+package deathmatch
+
+import (
+	"encoding/json"
+
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/game/deathmatch/mailboxmessages"
+)
+
+// Message said by an agent, kept for the viz frame (and thus the replay)
+type said struct {
+	from       ecs.EntityID
+	channel    string
+	payload    json.RawMessage
+	recipients []ecs.EntityID
+}
+
+// Delivers what agents said during the previous tick
+func systemMessaging(deathmatch *DeathmatchGame) {
+
+	deathmatch.says = make([]said, 0)
+
+	for _, entityresult := range deathmatch.messagingView.Get() {
+
+		messagingAspect := entityresult.Components[deathmatch.messagingComponent].(*Messaging)
+		physicalAspect := entityresult.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+
+		says := messagingAspect.PopPendingSays()
+		if len(says) == 0 {
+			continue
+		}
+
+		senderID := entityresult.Entity.GetID()
+		senderPosition := physicalAspect.GetPosition()
+		senderTeam := ""
+		if teamQr := deathmatch.getEntity(senderID, deathmatch.teamComponent); teamQr != nil {
+			senderTeam = teamQr.Components[deathmatch.teamComponent].(*Team).GetName()
+		}
+
+		for _, say := range says {
+
+			recipients := make([]ecs.EntityID, 0)
+
+			for _, recipientresult := range deathmatch.mailboxView.Get() {
+
+				recipientID := recipientresult.Entity.GetID()
+				if recipientID == senderID {
+					continue
+				}
+
+				switch say.channel {
+				case sayChannel.Team:
+					teamQr := deathmatch.getEntity(recipientID, deathmatch.teamComponent)
+					if teamQr == nil || teamQr.Components[deathmatch.teamComponent].(*Team).GetName() != senderTeam {
+						continue
+					}
+				case sayChannel.Radius:
+					recipientPhysicalAspect := recipientresult.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+					if recipientPhysicalAspect.GetPosition().Sub(senderPosition).Mag() > say.radius {
+						continue
+					}
+				default:
+					continue
+				}
+
+				mailboxAspect := recipientresult.Components[deathmatch.mailboxComponent].(*Mailbox)
+				mailboxAspect.PushMessage(mailboxmessages.YouHaveHeard{
+					From:    senderID.String(),
+					Channel: say.channel,
+					Payload: say.payload,
+				})
+
+				recipients = append(recipients, recipientID)
+			}
+
+			deathmatch.says = append(deathmatch.says, said{
+				from:       senderID,
+				channel:    say.channel,
+				payload:    say.payload,
+				recipients: recipients,
+			})
+		}
+	}
+}
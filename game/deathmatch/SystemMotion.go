@@ -0,0 +1,44 @@
+package deathmatch
+
+import (
+	"math"
+
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+// Drives kinematic obstacles along their path; Box2D moves them during the world step
+func systemMotion(deathmatch *DeathmatchGame) {
+
+	tps := float64(deathmatch.gameDescription.GetTps())
+
+	for _, entityresult := range deathmatch.motionView.Get() {
+
+		motionAspect := entityresult.Components[deathmatch.motionComponent].(*Motion)
+		physicalAspect := entityresult.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+		body := physicalAspect.GetBody()
+
+		velocity := vector.MakeNullVector2()
+
+		if len(motionAspect.waypoints) > 1 && motionAspect.speed > 0 {
+			position := physicalAspect.GetPhysicalReferentialPosition().Vector2()
+			tonext := motionAspect.waypoints[motionAspect.nextWaypoint].Sub(position)
+
+			if tonext.Mag() <= motionAspect.speed {
+				// reaching the waypoint during this tick
+				motionAspect.advanceWaypoint()
+			}
+
+			// never overshooting the waypoint
+			velocity = tonext.SetMag(math.Min(tonext.Mag(), motionAspect.speed))
+		}
+
+		// Box2D velocities are expressed in m/s
+		body.SetLinearVelocity(velocity.Scale(tps).ToB2Vec2())
+
+		if motionAspect.isRotating(deathmatch.ticknum) {
+			body.SetAngularVelocity(motionAspect.angularSpeed * tps)
+		} else {
+			body.SetAngularVelocity(0)
+		}
+	}
+}
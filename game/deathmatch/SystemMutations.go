@@ -3,6 +3,7 @@ package deathmatch
 import (
 	json "encoding/json"
 	"errors"
+	"math"
 
 	"github.com/bytearena/ecs"
 
@@ -28,6 +29,18 @@ func systemMutations(deathmatch *DeathmatchGame, mutations []types.AgentMutation
 		// Ordering actions
 		// This is important because operations like shooting are taken from the previous position of the agent
 		// 1. Non-movement actions (shoot, etc.)
+		// Messages are delivered next tick
+		for _, mutation := range batch.Mutations {
+			switch mutation.GetMethod() {
+			case "say":
+				{
+					if err := handleSayMutationMessage(deathmatch, batch.AgentEntityId, mutation); err != nil {
+						utils.Debug("arenaserver-mutation", err.Error()+"; coming from agent "+batch.AgentProxyUUID.String())
+					}
+				}
+			}
+		}
+
 		// 2. Movement actions
 
 		// 1. No movement actions
@@ -69,26 +82,89 @@ func systemMutations(deathmatch *DeathmatchGame, mutations []types.AgentMutation
 
 func handleShootMutationMessage(deathmatch *DeathmatchGame, entityID ecs.EntityID, mutation types.AgentMessagePayloadActions) error {
 
-	var aimingFloats []float64
-	err := json.Unmarshal(mutation.GetArguments(), &aimingFloats)
-	if err != nil {
+	// Arguments: [aimingX, aimingY] or [aimingX, aimingY, "weapon"]
+	var arguments []json.RawMessage
+	err := json.Unmarshal(mutation.GetArguments(), &arguments)
+	if err != nil || len(arguments) < 2 {
 		return errors.New("Failed to unmarshal JSON arguments for shoot mutation")
 	}
 
+	var aimingX, aimingY float64
+	if json.Unmarshal(arguments[0], &aimingX) != nil || json.Unmarshal(arguments[1], &aimingY) != nil {
+		return errors.New("Failed to unmarshal JSON aiming for shoot mutation")
+	}
+
+	weapon := ""
+	if len(arguments) > 2 {
+		if err := json.Unmarshal(arguments[2], &weapon); err != nil {
+			return errors.New("Failed to unmarshal JSON weapon for shoot mutation")
+		}
+	}
+
 	entityresult := deathmatch.getEntity(entityID, deathmatch.shootingComponent)
 	if entityresult == nil {
 		return errors.New("Failed to find entity associated to shoot mutation")
 	}
 
-	aiming := vector.MakeVector2(aimingFloats[0], aimingFloats[1]) //.
-	//Transform(deathmatch.physicalToAgentSpaceInverseTransform)
+	aiming := vector.MakeVector2(aimingX, aimingY)
 
 	shootingAspect := entityresult.Components[deathmatch.shootingComponent].(*Shooting)
-	shootingAspect.PushShot(aiming)
+	if shootingAspect.GetWeapon(weapon) == nil {
+		return errors.New("Unknown weapon " + weapon + " for shoot mutation")
+	}
+
+	shootingAspect.PushShot(aiming, weapon)
 
 	return nil
 }
 
+func handleSayMutationMessage(deathmatch *DeathmatchGame, entityID ecs.EntityID, mutation types.AgentMessagePayloadActions) error {
+
+	// Arguments: [payload], [payload, "team"] or [payload, radius]
+	var arguments []json.RawMessage
+	err := json.Unmarshal(mutation.GetArguments(), &arguments)
+	if err != nil || len(arguments) < 1 {
+		return errors.New("Failed to unmarshal JSON arguments for say mutation")
+	}
+
+	entityresult := deathmatch.getEntity(entityID, deathmatch.messagingComponent)
+	if entityresult == nil {
+		return errors.New("Failed to find entity associated to say mutation")
+	}
+
+	messagingAspect := entityresult.Components[deathmatch.messagingComponent].(*Messaging)
+	hasTeam := deathmatch.getEntity(entityID, deathmatch.teamComponent) != nil
+
+	// Defaults to the team if any, to the agents around otherwise
+	channel := sayChannel.Radius
+	radius := messagingAspect.MaxRadius
+	if hasTeam {
+		channel = sayChannel.Team
+	}
+
+	if len(arguments) > 1 {
+		var name string
+		if json.Unmarshal(arguments[1], &name) == nil {
+			if name != sayChannel.Team {
+				return errors.New("Unknown channel " + name + " for say mutation")
+			}
+
+			if !hasTeam {
+				return errors.New("Cannot say to team when not in a team")
+			}
+
+			channel = sayChannel.Team
+		} else if json.Unmarshal(arguments[1], &radius) == nil && radius > 0 {
+			channel = sayChannel.Radius
+			radius = math.Min(radius, messagingAspect.MaxRadius)
+		} else {
+			return errors.New("Failed to unmarshal JSON channel for say mutation")
+		}
+	}
+
+	return messagingAspect.PushSay(arguments[0], channel, radius)
+}
+
 func handleSteerMutationMessage(deathmatch *DeathmatchGame, entityID ecs.EntityID, mutation types.AgentMessagePayloadActions) error {
 	var steeringFloats []float64
 	err := json.Unmarshal(mutation.GetArguments(), &steeringFloats)
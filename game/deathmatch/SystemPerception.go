@@ -2,13 +2,12 @@ package deathmatch
 
 import (
 	"math"
-	"strconv"
-	"sync"
 
 	"github.com/bytearena/box2d"
 	"github.com/bytearena/ecs"
 
 	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/utils/space"
 	"github.com/bytearena/core/common/utils/trigo"
 	"github.com/bytearena/core/common/utils/vector"
 	"github.com/bytearena/core/common/visibility2d"
@@ -25,32 +24,28 @@ var threepi2 = math.Pi + halfpi
 // http://ncase.me/sight-and-light/
 
 func systemPerception(deathmatch *DeathmatchGame, mailboxes map[ecs.EntityID]([]mailboxmessages.MailboxMessageInterface)) {
+	deathmatch.spatialIndex.refresh(deathmatch)
+
 	entitiesWithPerception := deathmatch.perceptorsView.Get()
-	wg := sync.WaitGroup{}
-	wg.Add(len(entitiesWithPerception))
 
-	for _, entityResult := range entitiesWithPerception {
+	// Bounded by the number of workers, not by the number of perceptors
+	deathmatch.perceptionPool.Run(len(entitiesWithPerception), func(i int) {
+		entityResult := entitiesWithPerception[i]
 		perceptionAspect := entityResult.Components[deathmatch.perceptionComponent].(*Perception)
-		go func(perceptionAspect *Perception, entity *ecs.Entity, wg *sync.WaitGroup) {
-
-			entityID := entity.GetID()
-
-			messages, ok := mailboxes[entityID]
-			if !ok {
-				messages = nil
-			}
+		entityID := entityResult.Entity.GetID()
 
-			perceptionAspect.SetPerception(computeAgentPerception(
-				deathmatch,
-				deathmatch.gameDescription.GetMapContainer(),
-				entity.GetID(),
-				messages,
-			))
-			wg.Done()
-		}(perceptionAspect, entityResult.Entity, &wg)
-	}
+		messages, ok := mailboxes[entityID]
+		if !ok {
+			messages = nil
+		}
 
-	wg.Wait()
+		perceptionAspect.SetPerception(computeAgentPerception(
+			deathmatch,
+			deathmatch.gameDescription.GetMapContainer(),
+			entityID,
+			messages,
+		))
+	})
 }
 
 func computeAgentPerception(game *DeathmatchGame, arenaMap *mapcontainer.MapContainer, entityid ecs.EntityID, messages []mailboxmessages.MailboxMessageInterface) *agentPerception {
@@ -82,11 +77,40 @@ func computeAgentPerception(game *DeathmatchGame, arenaMap *mapcontainer.MapCont
 
 	//watch.Start("p.External.Vision =")
 	p.Vision = computeAgentVision(game, entityresult.Entity, physicalAspect, perceptionAspect)
+	if game.perceptionNoise != nil {
+		p.Vision = game.perceptionNoise.degradeVision(game.ticknum, entityid, perceptionAspect, p.Vision)
+	}
 	//watch.Stop("p.External.Vision =")
 
 	// watch.Stop("global")
 	// fmt.Println(watch.String())
 
+	if rangefinder := perceptionAspect.GetRangefinder(); rangefinder != nil {
+		p.Rangefinder = computeAgentRangefinder(game, entityid, physicalAspect, rangefinder)
+	}
+
+	if hearing := perceptionAspect.GetHearing(); hearing != nil {
+		p.Hearing = computeAgentHearing(game, entityid, physicalAspect, hearing)
+	}
+
+	if teamQr := game.getEntity(entityid, game.teamComponent); teamQr != nil {
+		p.Team = teamQr.Components[game.teamComponent].(*Team).GetName()
+	}
+
+	if game.ctf != nil {
+		p.Flags = computeAgentPerceptionFlags(game)
+	}
+
+	if game.koth != nil {
+		p.Zone = &agentPerceptionZone{
+			Holder:    game.koth.holder,
+			Contested: game.koth.contested,
+			Inside:    game.koth.occupants[entityid],
+			Score:     game.koth.scores[game.getZoneHolderName(entityid)],
+			ScoreCap:  game.koth.scoreCap,
+		}
+	}
+
 	p.Messages = make([]mailboxMessagePerceptionWrapper, 0)
 
 	if messages != nil {
@@ -104,12 +128,37 @@ func computeAgentPerception(game *DeathmatchGame, arenaMap *mapcontainer.MapCont
 	return p
 }
 
+func computeAgentPerceptionFlags(game *DeathmatchGame) []agentPerceptionFlag {
+	flags := make([]agentPerceptionFlag, 0)
+
+	for _, team := range game.teams {
+		_, flagAspect := game.getFlag(team)
+		if flagAspect == nil {
+			continue
+		}
+
+		flag := agentPerceptionFlag{
+			Team:   team,
+			AtHome: flagAspect.IsAtHome(),
+		}
+
+		if carrier, carried := flagAspect.GetCarrier(); carried {
+			flag.Carrier = carrier.String()
+		}
+
+		flags = append(flags, flag)
+	}
+
+	return flags
+}
+
 func computeAgentVision(game *DeathmatchGame, entity *ecs.Entity, physicalAspect *PhysicalBody, perceptionAspect *Perception) []agentPerceptionVisionItem {
 
 	//watch := utils.MakeStopwatch("viewEntities()")
 	//watch.Start("global")
 
-	vision := make([]agentPerceptionVisionItem, 0)
+	scratch := perceptionAspect.getScratch()
+	vision := scratch.vision[:0]
 
 	// for _, entityresult := range game.physicalView.Get() {
 	// 	physicalAspect := entityresult.Components[game.physicalBodyComponent].(*PhysicalBody)
@@ -169,17 +218,158 @@ func computeAgentVision(game *DeathmatchGame, entity *ecs.Entity, physicalAspect
 	}
 
 	entityAABB := vector.GetAABBForPointList(notableVisionConePoints...)
-	elementsInAABB := make(map[ecs.EntityID]commontypes.PhysicalBodyDescriptor)
 
-	game.PhysicalWorld.QueryAABB(func(fixture *box2d.B2Fixture) bool {
-		if descriptor, ok := fixture.GetBody().GetUserData().(commontypes.PhysicalBodyDescriptor); ok {
-			//elementsInAABB = append(elementsInAABB, descriptor)
-			if _, isInMap := elementsInAABB[descriptor.ID]; !isInMap {
-				elementsInAABB[descriptor.ID] = descriptor
+	// Obstacle segments; points in agent referential
+	// movingPhysicalAspect: body of kinematic obstacles, whose segments are seen with a velocity; nil otherwise
+	viewSegment := func(pointA, pointB vector.Vector2, entityID ecs.EntityID, segmentNumber int, movingPhysicalAspect *PhysicalBody) {
+
+		if !vector.GetAABBForPointList(pointA, pointB).Overlaps(entityAABB) {
+			return
+		}
+
+		edges := scratch.edges[:0]
+
+		relvecA := pointA.Sub(agentPosition)
+		relvecB := pointB.Sub(agentPosition)
+
+		distsqA := relvecA.MagSq()
+		distsqB := relvecB.MagSq()
+
+		// Comment déterminer si le vecteur entre dans le champ de vision ?
+		// => Intersection entre vecteur et segment gauche, droite
+
+		if distsqA <= visionRadiusSq {
+			// in radius
+			absAngleA := relvecA.Angle()
+			relAngleA := absAngleA - agentOrientation
+
+			// On passe de 0° / 360° à -180° / +180°
+			relAngleA = trigo.FullCircleAngleToSignedHalfCircleAngle(relAngleA)
+
+			if math.Abs(relAngleA) <= halfVisionAngle {
+				// point dans le champ de vision !
+				edges = append(edges, relvecA.Add(agentPosition))
+			} else {
+				//rejectededges = append(rejectededges, relvecA.Add(absoluteposition))
+			}
+		}
+
+		if distsqB <= visionRadiusSq {
+			absAngleB := relvecB.Angle()
+			relAngleB := absAngleB - agentOrientation
+
+			// On passe de 0° / 360° à -180° / +180°
+			relAngleB = trigo.FullCircleAngleToSignedHalfCircleAngle(relAngleB)
+
+			if math.Abs(relAngleB) <= halfVisionAngle {
+				// point dans le champ de vision !
+				edges = append(edges, relvecB.Add(agentPosition))
+			} else {
+				//rejectededges = append(rejectededges, relvecB.Add(absoluteposition))
+			}
+		}
+
+		{
+			// Sur les bords de la perception
+			if point, intersects, colinear, _ := trigo.IntersectionWithLineSegment(vector.MakeNullVector2(), leftVisionRelvec, relvecA, relvecB); intersects && !colinear {
+				// INTERSECT LEFT
+				edges = append(edges, point.Add(agentPosition))
+			}
+
+			if point, intersects, colinear, _ := trigo.IntersectionWithLineSegment(vector.MakeNullVector2(), rightVisionRelvec, relvecA, relvecB); intersects && !colinear {
+				// INTERSECT RIGHT
+				edges = append(edges, point.Add(agentPosition))
+			}
+		}
+
+		{
+			// Sur l'horizon de perception (arc de cercle)
+			intersections := trigo.LineCircleIntersectionPoints(
+				relvecA,
+				relvecB,
+				vector.MakeNullVector2(),
+				visionRadius,
+			)
+
+			for _, point := range intersections {
+				// il faut vérifier que le point se trouve bien sur le segment
+				// il faut vérifier que l'angle du point de collision se trouve bien dans le champ de vision de l'agent
+
+				if trigo.PointOnLineSegment(point, relvecA, relvecB) {
+					relvecangle := point.Angle() - agentOrientation
+
+					// On passe de 0° / 360° à -180° / +180°
+					relvecangle = trigo.FullCircleAngleToSignedHalfCircleAngle(relvecangle)
+
+					if math.Abs(relvecangle) <= halfVisionAngle {
+						edges = append(edges, point.Add(agentPosition))
+					} else {
+						//rejectededges = append(rejectededges, point.Add(absoluteposition))
+					}
+				} else {
+					//rejectededges = append(rejectededges, point.Add(absoluteposition))
+				}
+			}
+		}
+
+		scratch.edges = edges // keeping the grown buffer
+
+		if len(edges) == 2 {
+			edgeone := edges[0]
+			edgetwo := edges[1]
+			center := edgetwo.Add(edgeone).DivScalar(2)
+
+			//visiblemag := edgetwo.Sub(edgeone).Mag()
+
+			relCenter := center.Sub(agentPosition) // aligned on north
+			relCenterAngle := relCenter.Angle()
+			relCenterAgentAligned := relCenter.SetAngle(relCenterAngle - agentOrientation)
+
+			relEdgeOne := edgeone.Sub(agentPosition)
+			relEdgeTwo := edgetwo.Sub(agentPosition)
+
+			relEdgeOneAgentAligned := relEdgeOne.SetAngle(relEdgeOne.Angle() - agentOrientation)
+			relEdgeTwoAgentAligned := relEdgeTwo.SetAngle(relEdgeTwo.Angle() - agentOrientation)
+
+			var nearEdge, farEdge vector.Vector2
+			if relEdgeTwoAgentAligned.MagSq() > relEdgeOneAgentAligned.MagSq() {
+				nearEdge = relEdgeOneAgentAligned
+				farEdge = relEdgeTwoAgentAligned
+			} else {
+				nearEdge = relEdgeTwoAgentAligned
+				farEdge = relEdgeOneAgentAligned
 			}
+
+			velocity := vector.MakeNullVector2()
+			if movingPhysicalAspect != nil {
+				velocity = movingPhysicalAspect.GetVelocityAtPhysicalReferentialPoint(game.spaces.AgentToPhysical(space.MakeAgentVector2FromVector2(center)))
+				velocity = velocity.SetAngle(velocity.Angle() - agentOrientation)
+			}
+
+			obstacleperception := agentPerceptionVisionItem{
+				NearEdge:   nearEdge,
+				Center:     relCenterAgentAligned,
+				FarEdge:    farEdge,
+				Velocity:   velocity,
+				Tag:        agentPerceptionVisionItemTag.Obstacle,
+				EntityID:   entityID,
+				SegmentNum: segmentNumber,
+			}
+
+			vision = append(vision, obstacleperception)
+
+		} else if len(edges) > 0 {
+			// problems with FOV > 180
+			//log.Println("SOMETHING'S WRONG !!!!!!!!!!!!!!!!!!!", len(edges))
 		}
-		return true // keep going to find all fixtures in the query area
-	}, entityAABB.Transform(game.physicalToAgentSpaceInverseTransform).ToB2AABB())
+	}
+
+	// Static obstacle segments are indexed once; the other bodies are indexed every tick
+	for _, segment := range game.spatialIndex.searchStaticSegments(entityAABB) {
+		viewSegment(segment.pointA, segment.pointB, segment.entityID, segment.segmentNum, nil)
+	}
+
+	elementsInAABB := game.spatialIndex.searchDynamicBodies(entityAABB)
 
 	//log.Println("AABB:", len(elementsInAABB))
 
@@ -190,7 +380,7 @@ func computeAgentVision(game *DeathmatchGame, entity *ecs.Entity, physicalAspect
 			continue
 		}
 
-		if bodyDescriptor.Type == commontypes.PhysicalBodyDescriptorType.Agent || bodyDescriptor.Type == commontypes.PhysicalBodyDescriptorType.Projectile {
+		if bodyDescriptor.Type == commontypes.PhysicalBodyDescriptorType.Agent || bodyDescriptor.Type == commontypes.PhysicalBodyDescriptorType.Projectile || bodyDescriptor.Type == commontypes.PhysicalBodyDescriptorType.Pickup || bodyDescriptor.Type == commontypes.PhysicalBodyDescriptorType.Flag {
 
 			visionType := agentPerceptionVisionItemTag.Obstacle
 			switch bodyDescriptor.Type {
@@ -201,6 +391,12 @@ func computeAgentVision(game *DeathmatchGame, entity *ecs.Entity, physicalAspect
 			case commontypes.PhysicalBodyDescriptorType.Projectile:
 				visionType = agentPerceptionVisionItemTag.Projectile
 
+			case commontypes.PhysicalBodyDescriptorType.Pickup:
+				visionType = agentPerceptionVisionItemTag.Pickup
+
+			case commontypes.PhysicalBodyDescriptorType.Flag:
+				visionType = agentPerceptionVisionItemTag.Flag
+
 			case commontypes.PhysicalBodyDescriptorType.Obstacle:
 				visionType = agentPerceptionVisionItemTag.Obstacle
 			case commontypes.PhysicalBodyDescriptorType.Ground:
@@ -224,6 +420,14 @@ func computeAgentVision(game *DeathmatchGame, entity *ecs.Entity, physicalAspect
 				}
 			}
 
+			if bodyDescriptor.Type == commontypes.PhysicalBodyDescriptorType.Pickup {
+				pickupQr := game.getEntity(bodyDescriptor.ID, game.pickupComponent)
+				if pickupQr != nil && !pickupQr.Components[game.pickupComponent].(*Pickup).IsAvailable() {
+					// collected pickups are not visible until they respawn
+					continue
+				}
+			}
+
 			otherQr := game.getEntity(bodyDescriptor.ID, game.physicalBodyComponent)
 			otherPhysicalAspect := otherQr.Components[game.physicalBodyComponent].(*PhysicalBody)
 
@@ -274,157 +478,46 @@ func computeAgentVision(game *DeathmatchGame, entity *ecs.Entity, physicalAspect
 			otherQr := game.getEntity(bodyDescriptor.ID, game.physicalBodyComponent)
 			otherPhysicalAspect := otherQr.Components[game.physicalBodyComponent].(*PhysicalBody)
 
+			// kinematic obstacles move; their segments are seen with a velocity
+			isMoving := game.getEntity(bodyDescriptor.ID, game.motionComponent) != nil
+
 			segmentNumber := -1
 			fixture := otherPhysicalAspect.body.GetFixtureList()
 			for fixture != nil {
 
-				// Iterating over each segment of the polygon shape
-				segmentNumber++ // starts at 0
-
-				b2edge := fixture.GetShape().(*box2d.B2EdgeShape)
+				b2edge, ok := fixture.GetShape().(*box2d.B2EdgeShape)
 				fixture = fixture.M_next
 
-				pointA := vector.FromB2Vec2(b2edge.M_vertex1).Transform(game.physicalToAgentSpaceTransform)
-				pointB := vector.FromB2Vec2(b2edge.M_vertex2).Transform(game.physicalToAgentSpaceTransform)
-
-				if !vector.GetAABBForPointList(pointA, pointB).Overlaps(entityAABB) {
+				if !ok {
+					// convex pieces of solid obstacles; their outline is seen
 					continue
 				}
 
-				edges := make([]vector.Vector2, 0)
-
-				relvecA := pointA.Sub(agentPosition)
-				relvecB := pointB.Sub(agentPosition)
-
-				distsqA := relvecA.MagSq()
-				distsqB := relvecB.MagSq()
-
-				// Comment déterminer si le vecteur entre dans le champ de vision ?
-				// => Intersection entre vecteur et segment gauche, droite
-
-				if distsqA <= visionRadiusSq {
-					// in radius
-					absAngleA := relvecA.Angle()
-					relAngleA := absAngleA - agentOrientation
-
-					// On passe de 0° / 360° à -180° / +180°
-					relAngleA = trigo.FullCircleAngleToSignedHalfCircleAngle(relAngleA)
-
-					if math.Abs(relAngleA) <= halfVisionAngle {
-						// point dans le champ de vision !
-						edges = append(edges, relvecA.Add(agentPosition))
-					} else {
-						//rejectededges = append(rejectededges, relvecA.Add(absoluteposition))
-					}
-				}
-
-				if distsqB <= visionRadiusSq {
-					absAngleB := relvecB.Angle()
-					relAngleB := absAngleB - agentOrientation
-
-					// On passe de 0° / 360° à -180° / +180°
-					relAngleB = trigo.FullCircleAngleToSignedHalfCircleAngle(relAngleB)
-
-					if math.Abs(relAngleB) <= halfVisionAngle {
-						// point dans le champ de vision !
-						edges = append(edges, relvecB.Add(agentPosition))
-					} else {
-						//rejectededges = append(rejectededges, relvecB.Add(absoluteposition))
-					}
-				}
-
-				{
-					// Sur les bords de la perception
-					if point, intersects, colinear, _ := trigo.IntersectionWithLineSegment(vector.MakeNullVector2(), leftVisionRelvec, relvecA, relvecB); intersects && !colinear {
-						// INTERSECT LEFT
-						edges = append(edges, point.Add(agentPosition))
-					}
-
-					if point, intersects, colinear, _ := trigo.IntersectionWithLineSegment(vector.MakeNullVector2(), rightVisionRelvec, relvecA, relvecB); intersects && !colinear {
-						// INTERSECT RIGHT
-						edges = append(edges, point.Add(agentPosition))
-					}
-				}
-
-				{
-					// Sur l'horizon de perception (arc de cercle)
-					intersections := trigo.LineCircleIntersectionPoints(
-						relvecA,
-						relvecB,
-						vector.MakeNullVector2(),
-						visionRadius,
-					)
-
-					for _, point := range intersections {
-						// il faut vérifier que le point se trouve bien sur le segment
-						// il faut vérifier que l'angle du point de collision se trouve bien dans le champ de vision de l'agent
+				// Iterating over each segment of the polygon shape
+				segmentNumber++ // starts at 0
 
-						if trigo.PointOnLineSegment(point, relvecA, relvecB) {
-							relvecangle := point.Angle() - agentOrientation
+				// vertices are local to the body; static bodies sit at the origin
+				physicalPointA := space.PhysicalFromB2Vec2(otherPhysicalAspect.body.GetWorldPoint(b2edge.M_vertex1))
+				physicalPointB := space.PhysicalFromB2Vec2(otherPhysicalAspect.body.GetWorldPoint(b2edge.M_vertex2))
 
-							// On passe de 0° / 360° à -180° / +180°
-							relvecangle = trigo.FullCircleAngleToSignedHalfCircleAngle(relvecangle)
+				pointA := game.spaces.PhysicalToAgent(physicalPointA).Vector2()
+				pointB := game.spaces.PhysicalToAgent(physicalPointB).Vector2()
 
-							if math.Abs(relvecangle) <= halfVisionAngle {
-								edges = append(edges, point.Add(agentPosition))
-							} else {
-								//rejectededges = append(rejectededges, point.Add(absoluteposition))
-							}
-						} else {
-							//rejectededges = append(rejectededges, point.Add(absoluteposition))
-						}
-					}
+				var movingPhysicalAspect *PhysicalBody
+				if isMoving {
+					movingPhysicalAspect = otherPhysicalAspect
 				}
 
-				if len(edges) == 2 {
-					edgeone := edges[0]
-					edgetwo := edges[1]
-					center := edgetwo.Add(edgeone).DivScalar(2)
-
-					//visiblemag := edgetwo.Sub(edgeone).Mag()
-
-					relCenter := center.Sub(agentPosition) // aligned on north
-					relCenterAngle := relCenter.Angle()
-					relCenterAgentAligned := relCenter.SetAngle(relCenterAngle - agentOrientation)
-
-					relEdgeOne := edgeone.Sub(agentPosition)
-					relEdgeTwo := edgetwo.Sub(agentPosition)
-
-					relEdgeOneAgentAligned := relEdgeOne.SetAngle(relEdgeOne.Angle() - agentOrientation)
-					relEdgeTwoAgentAligned := relEdgeTwo.SetAngle(relEdgeTwo.Angle() - agentOrientation)
-
-					var nearEdge, farEdge vector.Vector2
-					if relEdgeTwoAgentAligned.MagSq() > relEdgeOneAgentAligned.MagSq() {
-						nearEdge = relEdgeOneAgentAligned
-						farEdge = relEdgeTwoAgentAligned
-					} else {
-						nearEdge = relEdgeTwoAgentAligned
-						farEdge = relEdgeOneAgentAligned
-					}
-
-					obstacleperception := agentPerceptionVisionItem{
-						NearEdge:   nearEdge,
-						Center:     relCenterAgentAligned,
-						FarEdge:    farEdge,
-						Velocity:   vector.MakeNullVector2(),
-						Tag:        agentPerceptionVisionItemTag.Obstacle,
-						EntityID:   bodyDescriptor.ID,
-						SegmentNum: segmentNumber,
-					}
-
-					vision = append(vision, obstacleperception)
-
-				} else if len(edges) > 0 {
-					// problems with FOV > 180
-					//log.Println("SOMETHING'S WRONG !!!!!!!!!!!!!!!!!!!", len(edges))
-				}
+				viewSegment(pointA, pointB, bodyDescriptor.ID, segmentNumber, movingPhysicalAspect)
 			}
 		}
 	}
 
-	vision = processOcclusions(vision, agentPosition)
+	// Obstacle segments and circular bodies are occluded alike
+	// Vision items are relative to the agent: the sweep is centered on the origin
+	scratch.vision = vision // keeping the grown buffer
 
-	return vision
+	return processOcclusions(vision, vector.MakeNullVector2(), scratch)
 }
 
 type occlusionItem struct {
@@ -442,22 +535,28 @@ func (a byAngleRatio) Len() int           { return len(a) }
 func (a byAngleRatio) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a byAngleRatio) Less(i, j int) bool { return a[i].angleRatioFrom < a[j].angleRatioFrom }
 
-func processOcclusions(vision []agentPerceptionVisionItem, agentPosition vector.Vector2) []agentPerceptionVisionItem {
+// scratch: buffers reused from one tick to the next; nil => allocated for this call
+func processOcclusions(vision []agentPerceptionVisionItem, agentPosition vector.Vector2, scratch *perceptionScratch) []agentPerceptionVisionItem {
 	//return vision
 
+	if scratch == nil {
+		scratch = newPerceptionScratch()
+	}
+
 	// Breaking segments at intersections
 
-	breakableSegments := make([]visibility2d.ObstacleSegment, len(vision))
+	breakableSegments := scratch.breakableSegments[:0]
 	for i := 0; i < len(vision); i++ {
-		v := vision[i]
-		breakableSegments[i] = visibility2d.ObstacleSegment{
+		v := &vision[i] // a pointer does not allocate when boxed in UserData
+		breakableSegments = append(breakableSegments, visibility2d.ObstacleSegment{
 			Points: [2][2]float64{
 				v.NearEdge,
 				v.FarEdge,
 			},
 			UserData: v,
-		}
+		})
 	}
+	scratch.breakableSegments = breakableSegments
 
 	brokenSegments := visibility2d.OnlyVisible(
 		agentPosition,
@@ -485,27 +584,30 @@ func processOcclusions(vision []agentPerceptionVisionItem, agentPosition vector.
 	*/
 
 	// Sorting the broken segments by entity+segmentnum
-	sortedSegments := map[string]([]*visibility2d.ObstacleSegment){}
+	sortedSegments := scratch.sortedSegments
+	for key, collection := range sortedSegments {
+		if len(collection) == 0 {
+			// not seen during the previous call
+			delete(sortedSegments, key)
+			continue
+		}
+
+		sortedSegments[key] = collection[:0]
+	}
+
 	for i, _ := range brokenSegments {
 
 		brokensegment := &brokenSegments[i]
 
-		visionItem := brokensegment.UserData.(agentPerceptionVisionItem)
-		segmentHash := strconv.Itoa(int(visionItem.EntityID)) + ":" + strconv.Itoa(visionItem.SegmentNum)
-
-		var collection []*visibility2d.ObstacleSegment
-		var found bool
-		if collection, found = sortedSegments[segmentHash]; !found {
-			collection = make([]*visibility2d.ObstacleSegment, 0)
-		}
+		visionItem := brokensegment.UserData.(*agentPerceptionVisionItem)
+		segmentKey := getVisionItemKey(*visionItem)
 
-		collection = append(collection, brokensegment)
-		sortedSegments[segmentHash] = collection
+		sortedSegments[segmentKey] = append(sortedSegments[segmentKey], brokensegment)
 	}
 
 	precision := 0.001
 
-	finalSegments := make([]*visibility2d.ObstacleSegment, 0)
+	finalSegments := scratch.finalSegments[:0]
 
 	for _, collection := range sortedSegments {
 		mustIterate := true
@@ -621,9 +723,31 @@ func processOcclusions(vision []agentPerceptionVisionItem, agentPosition vector.
 
 		finalSegments = append(finalSegments, collection...)
 	}
+	scratch.finalSegments = finalSegments
 
 	//fmt.Println("FROM", lenbefore, "TO", len(finalSegments))
 
+	// Visible ratio of each item, by entity+segmentnum
+	fullLengths := scratch.fullLengths
+	for key := range fullLengths {
+		delete(fullLengths, key)
+	}
+
+	for _, v := range vision {
+		fullLengths[getVisionItemKey(v)] += v.FarEdge.Sub(v.NearEdge).Mag()
+	}
+
+	visibleLengths := scratch.visibleLengths
+	for key := range visibleLengths {
+		delete(visibleLengths, key)
+	}
+
+	for _, brokenSegment := range finalSegments {
+		key := getVisionItemKey(*brokenSegment.UserData.(*agentPerceptionVisionItem))
+		visibleLengths[key] += vector.Vector2(brokenSegment.Points[1]).Sub(brokenSegment.Points[0]).Mag()
+	}
+
+	// Not a scratch buffer: the perception is read after the tick, when sent to the agent
 	realVision := make([]agentPerceptionVisionItem, len(finalSegments))
 
 	//fmt.Println("--------------------------------------------------")
@@ -642,7 +766,13 @@ func processOcclusions(vision []agentPerceptionVisionItem, agentPosition vector.
 			nearEdge, farEdge = b, a
 		}
 
-		data := brokenSegment.UserData.(agentPerceptionVisionItem)
+		data := brokenSegment.UserData.(*agentPerceptionVisionItem)
+
+		key := getVisionItemKey(*data)
+		visibility := 1.0
+		if fullLengths[key] > 0 {
+			visibility = math.Min(1, visibleLengths[key]/fullLengths[key])
+		}
 
 		realVision[i] = agentPerceptionVisionItem{
 			Tag:        data.Tag,
@@ -650,6 +780,7 @@ func processOcclusions(vision []agentPerceptionVisionItem, agentPosition vector.
 			FarEdge:    farEdge,
 			Center:     obs.Center(),
 			Velocity:   data.Velocity,
+			Visibility: visibility,
 			EntityID:   data.EntityID,
 			SegmentNum: data.SegmentNum,
 		}
@@ -661,6 +792,43 @@ func processOcclusions(vision []agentPerceptionVisionItem, agentPosition vector.
 
 }
 
+// Identifies the segment an item comes from, before occlusion breaks it
+type visionItemKey struct {
+	entityID   ecs.EntityID
+	segmentNum int
+}
+
+func getVisionItemKey(item agentPerceptionVisionItem) visionItemKey {
+	return visionItemKey{
+		entityID:   item.EntityID,
+		segmentNum: item.SegmentNum,
+	}
+}
+
+// Buffers of the perception of an agent, reused from one tick to the next
+// Each agent has its own: perceptions are computed concurrently
+type perceptionScratch struct {
+	vision            []agentPerceptionVisionItem
+	edges             []vector.Vector2
+	breakableSegments []visibility2d.ObstacleSegment
+	finalSegments     []*visibility2d.ObstacleSegment
+	sortedSegments    map[visionItemKey][]*visibility2d.ObstacleSegment
+	fullLengths       map[visionItemKey]float64
+	visibleLengths    map[visionItemKey]float64
+}
+
+func newPerceptionScratch() *perceptionScratch {
+	return &perceptionScratch{
+		vision:            make([]agentPerceptionVisionItem, 0),
+		edges:             make([]vector.Vector2, 0, 4),
+		breakableSegments: make([]visibility2d.ObstacleSegment, 0),
+		finalSegments:     make([]*visibility2d.ObstacleSegment, 0),
+		sortedSegments:    make(map[visionItemKey][]*visibility2d.ObstacleSegment),
+		fullLengths:       make(map[visionItemKey]float64),
+		visibleLengths:    make(map[visionItemKey]float64),
+	}
+}
+
 func getCircleSegmentAABB(center vector.Vector2, radius float64, angleARad float64, angleBRad float64) (lowerBound vector.Vector2, upperBound vector.Vector2) {
 	return vector.MakeVector2(0, 0), vector.MakeVector2(0, 0)
 }
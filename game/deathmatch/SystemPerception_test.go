@@ -0,0 +1,136 @@
+package deathmatch
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/bytearena/ecs"
+
+	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/common/utils/space"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+type benchmarkGameDescription struct {
+	arenaMap *mapcontainer.MapContainer
+}
+
+func (d benchmarkGameDescription) GetId() string                   { return "benchmark" }
+func (d benchmarkGameDescription) GetName() string                 { return "benchmark" }
+func (d benchmarkGameDescription) GetTps() int                     { return 10 }
+func (d benchmarkGameDescription) GetRunStatus() int               { return 0 }
+func (d benchmarkGameDescription) GetLaunchedAt() string           { return "" }
+func (d benchmarkGameDescription) GetEndedAt() string              { return "" }
+func (d benchmarkGameDescription) GetAgents() []*commontypes.Agent { return nil }
+func (d benchmarkGameDescription) GetMapContainer() *mapcontainer.MapContainer {
+	return d.arenaMap
+}
+
+func makeBenchmarkSquare(x, y, size float64) mapcontainer.MapPolygon {
+	return mapcontainer.MapPolygon{
+		Points: []mapcontainer.MapPoint{
+			{x, y},
+			{x + size, y},
+			{x + size, y + size},
+			{x, y + size},
+		},
+	}
+}
+
+// Square arena, with a grid of square pillars
+func makeBenchmarkMap(arenaSize float64, pillarSpacing float64) *mapcontainer.MapContainer {
+	arenaMap := &mapcontainer.MapContainer{}
+
+	arenaMap.Data.Grounds = []mapcontainer.MapPolygonObject{
+		{Name: "ground", Polygon: makeBenchmarkSquare(0, 0, arenaSize)},
+	}
+
+	for x := pillarSpacing; x < arenaSize; x += pillarSpacing {
+		for y := pillarSpacing; y < arenaSize; y += pillarSpacing {
+			arenaMap.Data.Obstacles = append(arenaMap.Data.Obstacles, mapcontainer.MapPolygonObject{
+				Name:    "pillar",
+				Polygon: makeBenchmarkSquare(x, y, pillarSpacing/5),
+			})
+		}
+	}
+
+	return arenaMap
+}
+
+func benchmarkPerception(b *testing.B, nbAgents int) {
+	arenaSize := 40.0
+	game := NewDeathmatchGame(benchmarkGameDescription{
+		arenaMap: makeBenchmarkMap(arenaSize, 4),
+	})
+	defer game.TearDown()
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < nbAgents; i++ {
+		game.NewEntityAgent(
+			&commontypes.Agent{},
+			space.MakeMapVector2(1+rnd.Float64()*(arenaSize-2), 1+rnd.Float64()*(arenaSize-2)),
+		)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		systemPerception(game, nil)
+	}
+}
+
+func BenchmarkPerception(b *testing.B) {
+	for _, nbAgents := range []int{10, 50, 200} {
+		b.Run(strconv.Itoa(nbAgents)+"agents", func(b *testing.B) {
+			benchmarkPerception(b, nbAgents)
+		})
+	}
+}
+
+// Segments scattered around the agent, as seen by computeAgentVision
+func makeBenchmarkVision(nbItems int) []agentPerceptionVisionItem {
+	rnd := rand.New(rand.NewSource(1))
+	vision := make([]agentPerceptionVisionItem, nbItems)
+
+	for i := range vision {
+		center := vector.MakeVector2(rnd.Float64()*200-100, rnd.Float64()*200-100)
+		halfEdge := vector.MakeVector2(rnd.Float64()*10-5, rnd.Float64()*10-5)
+
+		vision[i] = agentPerceptionVisionItem{
+			Tag:        agentPerceptionVisionItemTag.Obstacle,
+			NearEdge:   center.Sub(halfEdge),
+			Center:     center,
+			FarEdge:    center.Add(halfEdge),
+			EntityID:   ecs.EntityID(i),
+			SegmentNum: 0,
+		}
+	}
+
+	return vision
+}
+
+// At high TPS, the perception of every agent is computed many times per second;
+// reusing the buffers from one tick to the next spares the GC
+func BenchmarkProcessOcclusions(b *testing.B) {
+	vision := makeBenchmarkVision(100)
+
+	b.Run("fresh", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			processOcclusions(vision, vector.MakeNullVector2(), nil)
+		}
+	})
+
+	b.Run("scratch", func(b *testing.B) {
+		scratch := newPerceptionScratch()
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			processOcclusions(vision, vector.MakeNullVector2(), scratch)
+		}
+	})
+}
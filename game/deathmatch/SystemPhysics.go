@@ -24,11 +24,15 @@ func systemPhysics(deathmatch *DeathmatchGame, dt float64) {
 
 	//before := time.Now()
 
-	deathmatch.PhysicalWorld.Step(
-		dt,
-		4, // velocityIterations; higher improves stability; default 8 in testbed
-		2, // positionIterations; higher improve overlap resolution; default 3 in testbed
-	)
+	// Sub-steps improve precision for fast bodies; contacts of every sub-step are reported
+	profile := deathmatch.physics
+	for i := 0; i < profile.substeps; i++ {
+		deathmatch.PhysicalWorld.Step(
+			dt/float64(profile.substeps),
+			profile.velocityIterations, // default 8 in testbed
+			profile.positionIterations, // default 3 in testbed
+		)
+	}
 
 	//log.Println("Physical world step took ", float64(time.Now().UnixNano()-before.UnixNano())/1000000.0, "ms")
 }
@@ -0,0 +1,28 @@
+package deathmatch
+
+func systemPickups(deathmatch *DeathmatchGame) {
+
+	// Collected pickups become available again after their respawn delay
+	for _, entityresult := range deathmatch.pickupView.Get() {
+		pickupAspect := entityresult.Components[deathmatch.pickupComponent].(*Pickup)
+		if pickupAspect.available || deathmatch.ticknum < pickupAspect.respawnAt {
+			continue
+		}
+
+		physicalAspect := entityresult.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+		physicalAspect.GetBody().SetActive(true)
+		pickupAspect.available = true
+	}
+
+	// Expired speed boosts are removed
+	for _, entityresult := range deathmatch.speedBoostView.Get() {
+		boostAspect := entityresult.Components[deathmatch.speedBoostComponent].(*SpeedBoost)
+		if deathmatch.ticknum < boostAspect.until {
+			continue
+		}
+
+		physicalAspect := entityresult.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+		physicalAspect.SetMaxSpeed(boostAspect.baseMaxSpeed)
+		entityresult.Entity.RemoveComponent(deathmatch.speedBoostComponent)
+	}
+}
@@ -0,0 +1,242 @@
+package deathmatch
+
+import (
+	"sort"
+
+	"github.com/bytearena/box2d"
+	"github.com/bytearena/ecs"
+
+	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/utils/space"
+	"github.com/bytearena/core/common/utils/trigo"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+// Projectiles against agents and obstacles are hit-tested here, before the physics step,
+// instead of relying on Box2D bullet contacts; projectiles against projectiles are left to Box2D
+// Everything moves linearly during the tick, except the ends of rotating edges, each moving linearly on its own; agent referential
+
+type projectileHit struct {
+	projectileID ecs.EntityID
+	targetID     ecs.EntityID
+	toi          float64        // fraction of the tick, in [0, 1]
+	point        vector.Vector2 // on the target, at toi
+}
+
+type sweptBody struct {
+	id           ecs.EntityID
+	position     vector.Vector2 // at the beginning of the tick
+	displacement vector.Vector2 // during the tick
+	radius       float64
+}
+
+type sweptSegment struct {
+	id            ecs.EntityID
+	segment       vector.Segment2 // at the beginning of the tick
+	displacementA vector.Vector2  // of each end during the tick; they differ if the segment rotates
+	displacementB vector.Vector2
+}
+
+func isSweptPair(descriptorA, descriptorB commontypes.PhysicalBodyDescriptor) bool {
+	if descriptorB.Type == commontypes.PhysicalBodyDescriptorType.Projectile {
+		descriptorA, descriptorB = descriptorB, descriptorA
+	}
+
+	if descriptorA.Type != commontypes.PhysicalBodyDescriptorType.Projectile {
+		return false
+	}
+
+	return descriptorB.Type == commontypes.PhysicalBodyDescriptorType.Agent ||
+		descriptorB.Type == commontypes.PhysicalBodyDescriptorType.Obstacle
+}
+
+// Earliest hit of the projectile during the tick; ties are broken by target id
+func findProjectileHit(projectile sweptBody, bodies []sweptBody, segments []sweptSegment) (projectileHit, bool) {
+
+	var best projectileHit
+	found := false
+
+	consider := func(targetID ecs.EntityID, toi float64, point vector.Vector2) {
+		if found && (toi > best.toi || (toi == best.toi && targetID >= best.targetID)) {
+			return
+		}
+
+		best = projectileHit{
+			projectileID: projectile.id,
+			targetID:     targetID,
+			toi:          toi,
+			point:        point,
+		}
+		found = true
+	}
+
+	for _, body := range bodies {
+		// motion relative to the body, which is static in its own referential
+		relativeEnd := projectile.position.Add(projectile.displacement).Sub(body.displacement)
+
+		toi, point, hit := trigo.SweptCircleCircle(projectile.position, relativeEnd, projectile.radius, body.position, body.radius)
+		if hit {
+			consider(body.id, toi, point.Add(body.displacement.Scale(toi)))
+		}
+	}
+
+	for _, segment := range segments {
+		end := projectile.position.Add(projectile.displacement)
+
+		if segment.displacementA.Equals(segment.displacementB) {
+			// translation only
+			relativeEnd := end.Sub(segment.displacementA)
+
+			toi, point, hit := trigo.SweptCircleSegment(projectile.position, relativeEnd, projectile.radius, segment.segment)
+			if hit {
+				consider(segment.id, toi, point.Add(segment.displacementA.Scale(toi)))
+			}
+
+			continue
+		}
+
+		a, b := segment.segment.Get()
+		segmentEnd := vector.MakeSegment2(a.Add(segment.displacementA), b.Add(segment.displacementB))
+
+		toi, point, hit := trigo.SweptCircleMovingSegment(projectile.position, end, projectile.radius, segment.segment, segmentEnd)
+		if hit {
+			consider(segment.id, toi, point)
+		}
+	}
+
+	return best, found
+}
+
+// Impacts of the tick happen in time order; ties are broken by projectile id
+func sortProjectileHits(hits []projectileHit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].toi != hits[j].toi {
+			return hits[i].toi < hits[j].toi
+		}
+
+		return hits[i].projectileID < hits[j].projectileID
+	})
+}
+
+func getSweptEdges(game *DeathmatchGame, entityID ecs.EntityID, physicalAspect *PhysicalBody) []sweptSegment {
+	segments := make([]sweptSegment, 0)
+	body := physicalAspect.GetBody()
+
+	for fixture := body.GetFixtureList(); fixture != nil; fixture = fixture.GetNext() {
+		b2edge, ok := fixture.GetShape().(*box2d.B2EdgeShape)
+		if !ok {
+			continue
+		}
+
+		pointA := space.PhysicalFromB2Vec2(body.GetWorldPoint(b2edge.M_vertex1))
+		pointB := space.PhysicalFromB2Vec2(body.GetWorldPoint(b2edge.M_vertex2))
+
+		// rotating obstacles: the ends of an edge do not move at the same speed
+		segments = append(segments, sweptSegment{
+			id: entityID,
+			segment: vector.MakeSegment2(
+				game.spaces.PhysicalToAgent(pointA).Vector2(),
+				game.spaces.PhysicalToAgent(pointB).Vector2(),
+			),
+			displacementA: physicalAspect.GetVelocityAtPhysicalReferentialPoint(pointA),
+			displacementB: physicalAspect.GetVelocityAtPhysicalReferentialPoint(pointB),
+		})
+	}
+
+	return segments
+}
+
+func systemProjectileHits(deathmatch *DeathmatchGame) {
+
+	deathmatch.projectileHits = deathmatch.projectileHits[:0]
+
+	projectiles := make([]sweptBody, 0)
+	bodies := make([]sweptBody, 0)
+	movingSegments := make([]sweptSegment, 0)
+
+	for _, entityresult := range deathmatch.physicalView.Get() {
+		entityID := entityresult.Entity.GetID()
+		physicalAspect := entityresult.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+
+		if !physicalAspect.GetBody().IsActive() {
+			continue
+		}
+
+		descriptor, ok := physicalAspect.GetBody().GetUserData().(commontypes.PhysicalBodyDescriptor)
+		if !ok {
+			continue
+		}
+
+		switch descriptor.Type {
+		case commontypes.PhysicalBodyDescriptorType.Projectile:
+			lifecycleResult := deathmatch.getEntity(entityID, deathmatch.lifecycleComponent)
+			if lifecycleResult != nil && lifecycleResult.Components[deathmatch.lifecycleComponent].(*Lifecycle).GetDeath() > 0 {
+				// already landed
+				continue
+			}
+
+			projectiles = append(projectiles, sweptBody{
+				id:           entityID,
+				position:     physicalAspect.GetPosition(),
+				displacement: physicalAspect.GetVelocity(),
+				radius:       physicalAspect.GetRadius(),
+			})
+		case commontypes.PhysicalBodyDescriptorType.Agent:
+			bodies = append(bodies, sweptBody{
+				id:           entityID,
+				position:     physicalAspect.GetPosition(),
+				displacement: physicalAspect.GetVelocity(),
+				radius:       physicalAspect.GetRadius(),
+			})
+		case commontypes.PhysicalBodyDescriptorType.Obstacle:
+			if deathmatch.spatialIndex.staticEntities[entityID] {
+				// found through the spatial index
+				continue
+			}
+
+			movingSegments = append(movingSegments, getSweptEdges(deathmatch, entityID, physicalAspect)...)
+		}
+	}
+
+	for _, projectile := range projectiles {
+		if projectile.displacement.IsNull() {
+			continue
+		}
+
+		targets := make([]sweptBody, 0, len(bodies))
+		for _, body := range bodies {
+			if mayCollide(deathmatch, projectile.id, body.id) {
+				targets = append(targets, body)
+			}
+		}
+
+		segments := make([]sweptSegment, 0)
+		for _, segment := range movingSegments {
+			if mayCollide(deathmatch, projectile.id, segment.id) {
+				segments = append(segments, segment)
+			}
+		}
+
+		end := projectile.position.Add(projectile.displacement)
+		aabb := vector.GetAABBForPointList(projectile.position, end)
+		aabb.LowerBound = aabb.LowerBound.SubScalar(projectile.radius)
+		aabb.UpperBound = aabb.UpperBound.AddScalar(projectile.radius)
+
+		for _, static := range deathmatch.spatialIndex.searchStaticSegments(aabb) {
+			if mayCollide(deathmatch, projectile.id, static.entityID) {
+				segments = append(segments, sweptSegment{
+					id:            static.entityID,
+					segment:       vector.MakeSegment2(static.pointA, static.pointB),
+					displacementA: vector.MakeNullVector2(),
+					displacementB: vector.MakeNullVector2(),
+				})
+			}
+		}
+
+		if hit, ok := findProjectileHit(projectile, targets, segments); ok {
+			deathmatch.projectileHits = append(deathmatch.projectileHits, hit)
+		}
+	}
+
+	sortProjectileHits(deathmatch.projectileHits)
+}
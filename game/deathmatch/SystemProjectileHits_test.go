@@ -0,0 +1,143 @@
+package deathmatch
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/common/utils/trigo"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+func makeSweptBody(id ecs.EntityID, x, y, dx, dy, radius float64) sweptBody {
+	return sweptBody{
+		id:           id,
+		position:     vector.MakeVector2(x, y),
+		displacement: vector.MakeVector2(dx, dy),
+		radius:       radius,
+	}
+}
+
+func makeSweptWall(id ecs.EntityID, ax, ay, bx, by float64) sweptSegment {
+	return sweptSegment{
+		id:            id,
+		segment:       vector.MakeSegment2(vector.MakeVector2(ax, ay), vector.MakeVector2(bx, by)),
+		displacementA: vector.MakeNullVector2(),
+		displacementB: vector.MakeNullVector2(),
+	}
+}
+
+func TestFindProjectileHitThinWall(t *testing.T) {
+	// one tick takes the projectile from one side of the wall to the other
+	projectile := makeSweptBody(1, 0, 0, 200, 0, 5)
+	walls := []sweptSegment{
+		makeSweptWall(10, 150, -50, 150, 50),
+		makeSweptWall(11, 100, -50, 100, 50),
+	}
+
+	hit, ok := findProjectileHit(projectile, nil, walls)
+	if !ok {
+		t.Fatal("projectile tunneled through the walls")
+	}
+
+	if hit.targetID != 11 || math.Abs(hit.toi-0.475) > 0.000001 || !hit.point.EqualsWithPrecision(vector.MakeVector2(100, 0), 0.000001) {
+		t.Errorf("got %+v, expected the nearest wall at 0.475", hit)
+	}
+}
+
+func TestFindProjectileHitFastAgent(t *testing.T) {
+	// the agent crosses the line of fire during the tick; at the beginning and at the end of the tick, both are far apart
+	projectile := makeSweptBody(1, 0, 0, 100, 0, 1)
+	agent := makeSweptBody(2, 50, -50, 0, 100, 10)
+
+	hit, ok := findProjectileHit(projectile, []sweptBody{agent}, nil)
+	if !ok {
+		t.Fatal("fast agent missed")
+	}
+
+	// the point is on the agent, where it is at toi
+	agentCenter := agent.position.Add(agent.displacement.Scale(hit.toi))
+	if d := hit.point.Sub(agentCenter).Mag(); math.Abs(d-agent.radius) > 0.000001 {
+		t.Errorf("point %v is %f from the agent center, expected %f", hit.point, d, agent.radius)
+	}
+
+	// the same agent, a tick later, is out of the way
+	agent.position = agent.position.Add(agent.displacement)
+	if hit, ok := findProjectileHit(projectile, []sweptBody{agent}, nil); ok {
+		t.Errorf("unexpected hit %+v", hit)
+	}
+
+	// the agent runs away from the projectile, but not fast enough
+	fleeing := makeSweptBody(3, 60, 0, 40, 0, 10)
+	hit, ok = findProjectileHit(projectile, []sweptBody{fleeing}, nil)
+	if !ok || math.Abs(hit.toi-49.0/60.0) > 0.000001 {
+		t.Errorf("fleeing agent: got %+v %v", hit, ok)
+	}
+}
+
+func TestFindProjectileHitRotatingBar(t *testing.T) {
+	// bar pivoting around its first end, a quarter turn per tick: its tip moves fast, its pivot does not move
+	bar := makeSweptWall(10, 0, 0, 100, 0)
+	bar.displacementB = vector.MakeVector2(-100, 100)
+
+	// the projectile is swept by the bar, clear of it at the beginning and at the end of the tick
+	projectile := makeSweptBody(1, 30, 60, 0, -20, 1)
+
+	hit, ok := findProjectileHit(projectile, nil, []sweptSegment{bar})
+	if !ok {
+		t.Fatal("projectile went through the tip of the rotating bar")
+	}
+
+	if hit.targetID != 10 || hit.toi <= 0 || hit.toi >= 1 {
+		t.Errorf("got %+v", hit)
+	}
+
+	// the bar at toi is in contact with the projectile
+	center := projectile.position.Add(projectile.displacement.Scale(hit.toi))
+	tip := vector.MakeVector2(100, 0).Add(bar.displacementB.Scale(hit.toi))
+	if d := trigo.PointSegmentDistance(center, vector.MakeSegment2(vector.MakeVector2(0, 0), tip)); math.Abs(d-projectile.radius) > 0.0001 {
+		t.Errorf("projectile %f from the bar at toi, expected %f", d, projectile.radius)
+	}
+
+	// without its rotation, the bar is never on the way
+	bar.displacementB = vector.MakeNullVector2()
+	if hit, ok := findProjectileHit(projectile, nil, []sweptSegment{bar}); ok {
+		t.Errorf("unexpected hit %+v", hit)
+	}
+}
+
+func TestProjectileHitsOrder(t *testing.T) {
+	agent := makeSweptBody(5, 100, 0, 0, 0, 10)
+	wall := makeSweptWall(6, 100, -100, 100, 100)
+
+	// the agent stands in front of the wall: it shields it
+	if hit, ok := findProjectileHit(makeSweptBody(1, 0, 0, 200, 0, 1), []sweptBody{agent}, []sweptSegment{wall}); !ok || hit.targetID != 5 {
+		t.Errorf("got %+v %v, expected the agent", hit, ok)
+	}
+
+	// two shots landing at the same time, found in any order
+	hits := make([]projectileHit, 0)
+	for _, id := range []ecs.EntityID{3, 2, 4} {
+		projectile := makeSweptBody(id, 0, float64(id), 200, 0, 1)
+		if id == 4 {
+			// faster: lands first
+			projectile.displacement = vector.MakeVector2(400, 0)
+		}
+
+		hit, ok := findProjectileHit(projectile, nil, []sweptSegment{wall})
+		if !ok {
+			t.Fatalf("projectile %d missed", id)
+		}
+
+		hits = append(hits, hit)
+	}
+
+	sortProjectileHits(hits)
+
+	for i, expected := range []ecs.EntityID{4, 2, 3} {
+		if hits[i].projectileID != expected {
+			t.Fatalf("got %+v, expected projectiles in order 4, 2, 3", hits)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package deathmatch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/common/utils"
+	"github.com/bytearena/core/game/deathmatch/events"
+	"github.com/bytearena/core/game/deathmatch/mailboxmessages"
+)
+
+const raceCheckpointTagPrefix = "race:checkpoint:" // polygon objects; race:checkpoint:<order>
+const mazeExitTag = "maze:exit"                    // polygon object; the maze is a race with a single checkpoint
+
+type raceProgress struct {
+	nextCheckpoint     int // index of the checkpoint to reach
+	lap                int // completed laps
+	lapStart           int // tick
+	lastCheckpointTick int
+	lapTimes           []int // in ticks
+
+	finished   bool
+	finishTick int
+	rank       int
+}
+
+type raceState struct {
+	checkpoints []ecs.EntityID // in order
+	laps        int
+
+	started   bool
+	startTick int
+
+	endOnFirstFinish bool // the maze ends when the first agent gets out
+
+	progress   map[ecs.EntityID]*raceProgress
+	nbFinished int
+	over       bool
+}
+
+type raceCheckpointObject struct {
+	order  int
+	object mapcontainer.MapPolygonObject
+}
+
+func initRace(deathmatch *DeathmatchGame, arenaMap *mapcontainer.MapContainer) {
+
+	state := &raceState{
+		checkpoints:      make([]ecs.EntityID, 0),
+		laps:             1,
+		endOnFirstFinish: deathmatch.variant == "maze",
+		progress:         make(map[ecs.EntityID]*raceProgress),
+	}
+
+	if laps, ok := arenaMap.Meta.Options["laps"].(float64); ok && laps > 0 {
+		state.laps = int(laps)
+	}
+
+	deathmatch.race = state
+
+	checkpointObjects := make([]raceCheckpointObject, 0)
+	for _, otherObject := range arenaMap.Data.OtherPolygonObjects {
+		for _, tag := range otherObject.Tags {
+			if tag == mazeExitTag {
+				checkpointObjects = append(checkpointObjects, raceCheckpointObject{order: 0, object: otherObject})
+				break
+			}
+
+			if !strings.HasPrefix(tag, raceCheckpointTagPrefix) {
+				continue
+			}
+
+			order, err := strconv.Atoi(strings.TrimPrefix(tag, raceCheckpointTagPrefix))
+			if err != nil {
+				continue
+			}
+
+			checkpointObjects = append(checkpointObjects, raceCheckpointObject{order: order, object: otherObject})
+			break
+		}
+	}
+
+	sort.SliceStable(checkpointObjects, func(i, j int) bool {
+		return checkpointObjects[i].order < checkpointObjects[j].order
+	})
+
+	if len(checkpointObjects) < 2 {
+		// a lap needs at least two checkpoints; re-entering the same one is not a lap
+		state.laps = 1
+	}
+
+	for index, checkpointObject := range checkpointObjects {
+		checkpointIndex := index
+		state.checkpoints = append(state.checkpoints, deathmatch.NewEntitySensor(
+			checkpointObject.object.Polygon,
+			checkpointObject.object.Name,
+			func(entityid ecs.EntityID, sensorid ecs.EntityID) {
+				deathmatch.reachCheckpoint(entityid, checkpointIndex)
+			},
+			utils.BuildTag(
+				CollisionGroup.Agent,
+			),
+		).GetID())
+	}
+}
+
+func (deathmatch *DeathmatchGame) getRaceProgress(entityID ecs.EntityID) *raceProgress {
+	progress, ok := deathmatch.race.progress[entityID]
+	if !ok {
+		progress = &raceProgress{
+			lapStart:           deathmatch.race.startTick,
+			lastCheckpointTick: deathmatch.race.startTick,
+			lapTimes:           make([]int, 0),
+		}
+		deathmatch.race.progress[entityID] = progress
+	}
+
+	return progress
+}
+
+func (deathmatch *DeathmatchGame) reachCheckpoint(entityID ecs.EntityID, checkpointIndex int) {
+
+	state := deathmatch.race
+	if state.over {
+		return
+	}
+
+	if deathmatch.getEntity(entityID, deathmatch.playerComponent) == nil {
+		// only agents race
+		return
+	}
+
+	progress := deathmatch.getRaceProgress(entityID)
+	if progress.finished || progress.nextCheckpoint != checkpointIndex {
+		// checkpoints have to be reached in order
+		return
+	}
+
+	split := deathmatch.ticknum - progress.lastCheckpointTick
+	progress.lastCheckpointTick = deathmatch.ticknum
+	progress.nextCheckpoint++
+
+	lapTime := 0
+	if progress.nextCheckpoint == len(state.checkpoints) {
+		// lap completed
+		lapTime = deathmatch.ticknum - progress.lapStart
+		progress.lapTimes = append(progress.lapTimes, lapTime)
+		progress.lap++
+		progress.lapStart = deathmatch.ticknum
+		progress.nextCheckpoint = 0
+	}
+
+	deathmatch.BusPublish(events.EntityReachedCheckpoint{
+		Entity:     entityID,
+		Checkpoint: checkpointIndex,
+		Lap:        progress.lap,
+		Split:      split,
+		LapTime:    lapTime,
+	})
+
+	if progress.lap < state.laps {
+		return
+	}
+
+	state.nbFinished++
+	progress.finished = true
+	progress.finishTick = deathmatch.ticknum
+	progress.rank = state.nbFinished
+
+	deathmatch.BusPublish(events.EntityFinishedRace{
+		Entity: entityID,
+		Rank:   progress.rank,
+		Time:   progress.finishTick - state.startTick,
+	})
+}
+
+// Finishers first, by finish time; then the others by progress
+func (deathmatch *DeathmatchGame) getRaceRanking() []ecs.EntityID {
+
+	ranking := make([]ecs.EntityID, 0)
+	for _, result := range deathmatch.playerView.Get() {
+		ranking = append(ranking, result.Entity.GetID())
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		a := deathmatch.getRaceProgress(ranking[i])
+		b := deathmatch.getRaceProgress(ranking[j])
+
+		if a.finished != b.finished {
+			return a.finished
+		}
+
+		if a.finished {
+			return a.rank < b.rank
+		}
+
+		if a.lap != b.lap {
+			return a.lap > b.lap
+		}
+
+		if a.nextCheckpoint != b.nextCheckpoint {
+			return a.nextCheckpoint > b.nextCheckpoint
+		}
+
+		return a.lastCheckpointTick < b.lastCheckpointTick
+	})
+
+	return ranking
+}
+
+func systemRace(deathmatch *DeathmatchGame) {
+
+	state := deathmatch.race
+	if state == nil || state.over {
+		return
+	}
+
+	if !state.started {
+		state.started = true
+		state.startTick = deathmatch.ticknum
+	}
+
+	nbRacers := 0
+	for _, result := range deathmatch.playerView.Get() {
+		deathmatch.getRaceProgress(result.Entity.GetID())
+		nbRacers++
+	}
+
+	if state.nbFinished > 0 && (state.endOnFirstFinish || state.nbFinished >= nbRacers) {
+		state.over = true
+
+		// the finishers may have left the match since
+		winner := ""
+		if ranking := deathmatch.getRaceRanking(); len(ranking) > 0 && deathmatch.getRaceProgress(ranking[0]).finished {
+			winner = ranking[0].String()
+		}
+
+		deathmatch.endMatch(matchEndReason.Objective, winner)
+		return
+	}
+
+	// time limit of the match rules
+	if !deathmatch.isMatchTimeUp() {
+		return
+	}
+
+	///////////////////////////////////////////////////////////////////////////
+	// Temps écoulé : ceux qui n'ont pas fini sont DNF
+	///////////////////////////////////////////////////////////////////////////
+
+	state.over = true
+
+	ranking := deathmatch.getRaceRanking()
+	for position, entityID := range ranking {
+		progress := deathmatch.getRaceProgress(entityID)
+		if progress.finished {
+			continue
+		}
+
+		mailboxQr := deathmatch.getEntity(entityID, deathmatch.mailboxComponent)
+		if mailboxQr == nil {
+			continue
+		}
+
+		mailboxAspect := mailboxQr.Components[deathmatch.mailboxComponent].(*Mailbox)
+		mailboxAspect.PushMessage(mailboxmessages.DidNotFinish{
+			Position:   position + 1,
+			Lap:        progress.lap,
+			Checkpoint: progress.nextCheckpoint,
+		})
+	}
+
+	winner := ""
+	if len(ranking) > 0 && deathmatch.getRaceProgress(ranking[0]).finished {
+		winner = ranking[0].String()
+	}
+
+	deathmatch.endMatch(matchEndReason.TimeLimit, winner)
+}
+
+func (game *DeathmatchGame) onEntityReachedCheckpoint(e events.EntityReachedCheckpoint) {
+	query := game.getEntity(e.Entity, game.mailboxComponent)
+	if query == nil {
+		// should never happen
+		return
+	}
+
+	mailboxAspect := query.Components[game.mailboxComponent].(*Mailbox)
+	mailboxAspect.PushMessage(mailboxmessages.CheckpointReached{
+		Checkpoint:  e.Checkpoint,
+		Checkpoints: len(game.race.checkpoints),
+		Lap:         e.Lap,
+		Laps:        game.race.laps,
+		Split:       e.Split,
+		LapTime:     e.LapTime,
+	})
+}
+
+func (game *DeathmatchGame) onEntityFinishedRace(e events.EntityFinishedRace) {
+	query := game.getEntity(e.Entity, game.mailboxComponent)
+	if query == nil {
+		// should never happen
+		return
+	}
+
+	mailboxAspect := query.Components[game.mailboxComponent].(*Mailbox)
+	mailboxAspect.PushMessage(mailboxmessages.RaceFinished{
+		Rank:     e.Rank,
+		Time:     e.Time,
+		LapTimes: game.getRaceProgress(e.Entity).lapTimes,
+	})
+
+	if game.variant == "maze" {
+		mailboxAspect.PushMessage(mailboxmessages.YouHaveExitedTheMaze{
+			Entity: e.Entity,
+		})
+	}
+}
@@ -4,7 +4,7 @@ func systemRespawn(deathmatch *DeathmatchGame) {
 
 	for _, entityresult := range deathmatch.respawnView.Get() {
 		respawnAspect := entityresult.Components[deathmatch.respawnComponent].(*Respawn)
-		if respawnAspect.isRespawning {
+		if respawnAspect.isRespawning && !respawnAspect.eliminated {
 			respawnAspect.respawningCountdown--
 			if respawnAspect.respawningCountdown <= 0 {
 				respawnAspect.isRespawning = false
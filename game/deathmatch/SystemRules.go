@@ -0,0 +1,336 @@
+package deathmatch
+
+import (
+	"time"
+
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/game/deathmatch/events"
+	"github.com/bytearena/core/game/deathmatch/mailboxmessages"
+)
+
+var matchEndReason = struct {
+	FragLimit         string
+	ScoreLimit        string
+	TimeLimit         string
+	SuddenDeath       string
+	LastAgentStanding string
+	Objective         string // variant objective (flags captured, zone held, race finished)
+}{
+	FragLimit:         "fraglimit",
+	ScoreLimit:        "scorelimit",
+	TimeLimit:         "timelimit",
+	SuddenDeath:       "suddendeath",
+	LastAgentStanding: "lastagentstanding",
+	Objective:         "objective",
+}
+
+// Match rules; read from the "rules" option of the map
+type matchRules struct {
+	fragLimit         int           // 0 => no limit
+	scoreLimit        int           // 0 => no limit
+	timeLimit         int           // in ticks; 0 => no limit
+	wallClockLimit    time.Duration // 0 => no limit
+	suddenDeath       bool          // when time is up with a tie for the lead, the match goes on until the tie is broken
+	lastAgentStanding bool          // fragged agents do not respawn; the match ends when one agent (or team) is left
+}
+
+type rulesState struct {
+	rules matchRules
+
+	started        bool
+	startTick      int
+	startTime      time.Time
+	inSuddenDeath  bool
+	pendingEnd     *events.MatchEnded // requested during the tick, by the rules or by the variant
+	ended          bool
+	gameOverCalled bool
+}
+
+func makeMatchRules(arenaMap *mapcontainer.MapContainer) matchRules {
+	rules := matchRules{}
+
+	options, ok := arenaMap.Meta.Options["rules"].(map[string]interface{})
+	if !ok {
+		return rules
+	}
+
+	if fragLimit, ok := options["fraglimit"].(float64); ok && fragLimit > 0 {
+		rules.fragLimit = int(fragLimit)
+	}
+
+	if scoreLimit, ok := options["scorelimit"].(float64); ok && scoreLimit > 0 {
+		rules.scoreLimit = int(scoreLimit)
+	}
+
+	if timeLimit, ok := options["timelimit"].(float64); ok && timeLimit > 0 {
+		rules.timeLimit = int(timeLimit)
+	}
+
+	if duration, ok := options["duration"].(string); ok {
+		if wallClockLimit, err := time.ParseDuration(duration); err == nil && wallClockLimit > 0 {
+			rules.wallClockLimit = wallClockLimit
+		}
+	}
+
+	rules.suddenDeath, _ = options["suddendeath"].(bool)
+	rules.lastAgentStanding, _ = options["lastagentstanding"].(bool)
+
+	return rules
+}
+
+// Player as seen by the rules
+type rulesPlayer struct {
+	winner   string // its team if any, the agent otherwise
+	frags    int
+	score    int
+	standing bool // not eliminated
+}
+
+// What the rules decide at the end of a tick
+type rulesDecision struct {
+	end    bool
+	reason string
+	winner string
+
+	suddenDeath bool // time is up with a tie for the lead; the match goes on
+}
+
+func (rules matchRules) isTimeUp(elapsedTicks int, elapsed time.Duration) bool {
+	return (rules.timeLimit > 0 && elapsedTicks >= rules.timeLimit) ||
+		(rules.wallClockLimit > 0 && elapsed >= rules.wallClockLimit)
+}
+
+// Teammates play as one: frags add up; game modes already give every teammate the score of the team
+// Sides are in the order of their first player
+func groupRulesPlayers(players []rulesPlayer) []rulesPlayer {
+	sides := make([]rulesPlayer, 0)
+	indexes := make(map[string]int)
+
+	for _, player := range players {
+		i, ok := indexes[player.winner]
+		if !ok {
+			indexes[player.winner] = len(sides)
+			sides = append(sides, player)
+			continue
+		}
+
+		sides[i].frags += player.frags
+		if player.score > sides[i].score {
+			sides[i].score = player.score
+		}
+
+		sides[i].standing = sides[i].standing || player.standing
+	}
+
+	return sides
+}
+
+// Leader of the match by score; the first player wins ties, which are reported
+func getRulesLeader(players []rulesPlayer) (rulesPlayer, bool) {
+	var leader rulesPlayer
+	tied := false
+
+	for i, player := range players {
+		if i == 0 || player.score > leader.score {
+			leader = player
+			tied = false
+		} else if player.score == leader.score {
+			tied = true
+		}
+	}
+
+	return leader, tied
+}
+
+// The first limit reached wins, in the order of the players
+// Teams are ranked, and eliminated, as a whole
+func (rules matchRules) decide(players []rulesPlayer, timeIsUp bool, inSuddenDeath bool) rulesDecision {
+
+	players = groupRulesPlayers(players)
+
+	for _, player := range players {
+		if rules.fragLimit > 0 && player.frags >= rules.fragLimit {
+			return rulesDecision{end: true, reason: matchEndReason.FragLimit, winner: player.winner}
+		}
+
+		if rules.scoreLimit > 0 && player.score >= rules.scoreLimit {
+			return rulesDecision{end: true, reason: matchEndReason.ScoreLimit, winner: player.winner}
+		}
+	}
+
+	if rules.lastAgentStanding && len(players) > 1 {
+		nbStanding := 0
+		winner := ""
+
+		for _, player := range players {
+			if player.standing {
+				nbStanding++
+				winner = player.winner
+			}
+		}
+
+		if nbStanding <= 1 {
+			return rulesDecision{end: true, reason: matchEndReason.LastAgentStanding, winner: winner}
+		}
+	}
+
+	leader, tied := getRulesLeader(players)
+
+	if inSuddenDeath {
+		if !tied {
+			return rulesDecision{end: true, reason: matchEndReason.SuddenDeath, winner: leader.winner}
+		}
+
+		return rulesDecision{suddenDeath: true}
+	}
+
+	if !timeIsUp {
+		return rulesDecision{}
+	}
+
+	if rules.suddenDeath && tied {
+		return rulesDecision{suddenDeath: true}
+	}
+
+	winner := ""
+	if len(players) > 0 && !tied {
+		winner = leader.winner
+	}
+
+	return rulesDecision{end: true, reason: matchEndReason.TimeLimit, winner: winner}
+}
+
+func initRules(deathmatch *DeathmatchGame, arenaMap *mapcontainer.MapContainer) {
+	deathmatch.rules = &rulesState{
+		rules: makeMatchRules(arenaMap),
+	}
+}
+
+// Real time limit of the match; the server duration, if any
+func (deathmatch *DeathmatchGame) SetWallClockLimit(limit time.Duration) {
+	deathmatch.rules.rules.wallClockLimit = limit
+}
+
+// Requests the end of the match; effective at the end of the tick
+// The first request of the tick wins
+func (deathmatch *DeathmatchGame) endMatch(reason string, winner string) {
+	if deathmatch.rules.ended || deathmatch.rules.pendingEnd != nil {
+		return
+	}
+
+	deathmatch.rules.pendingEnd = &events.MatchEnded{
+		Reason: reason,
+		Winner: winner,
+		Tick:   deathmatch.ticknum,
+	}
+}
+
+// Winner of the leading agent: its team if any, the agent otherwise
+func (deathmatch *DeathmatchGame) getWinnerName(entityID ecs.EntityID) string {
+	if teamQr := deathmatch.getEntity(entityID, deathmatch.teamComponent); teamQr != nil {
+		return teamQr.Components[deathmatch.teamComponent].(*Team).GetName()
+	}
+
+	return entityID.String()
+}
+
+// Time limit of the match, in ticks or in real time; variants end on it as well
+func (deathmatch *DeathmatchGame) isMatchTimeUp() bool {
+	state := deathmatch.rules
+	if !state.started {
+		return false
+	}
+
+	return state.rules.isTimeUp(deathmatch.ticknum-state.startTick, time.Since(state.startTime))
+}
+
+func systemRules(deathmatch *DeathmatchGame) {
+
+	state := deathmatch.rules
+	if state.ended {
+		return
+	}
+
+	rules := state.rules
+
+	if !state.started {
+		state.started = true
+		state.startTick = deathmatch.ticknum
+		state.startTime = time.Now()
+	}
+
+	///////////////////////////////////////////////////////////////////////////
+	// Limites, dernier debout, temps écoulé et mort subite
+	///////////////////////////////////////////////////////////////////////////
+
+	players := make([]rulesPlayer, 0)
+
+	for _, result := range deathmatch.playerView.Get() {
+		playerAspect := result.Components[deathmatch.playerComponent].(*Player)
+		entityID := result.Entity.GetID()
+
+		standing := true
+		if respawnQr := deathmatch.getEntity(entityID, deathmatch.respawnComponent); respawnQr != nil {
+			standing = !respawnQr.Components[deathmatch.respawnComponent].(*Respawn).eliminated
+		}
+
+		players = append(players, rulesPlayer{
+			winner:   deathmatch.getWinnerName(entityID),
+			frags:    int(playerAspect.Stats.nbHasFragged),
+			score:    playerAspect.Score,
+			standing: standing,
+		})
+	}
+
+	decision := rules.decide(players, deathmatch.isMatchTimeUp(), state.inSuddenDeath)
+
+	if decision.suddenDeath && !state.inSuddenDeath {
+		state.inSuddenDeath = true
+		deathmatch.broadcastMessage(mailboxmessages.SuddenDeath{})
+	}
+
+	if decision.end {
+		deathmatch.endMatch(decision.reason, decision.winner)
+	}
+
+	if state.pendingEnd == nil {
+		return
+	}
+
+	state.ended = true
+	deathmatch.BusPublish(*state.pendingEnd)
+}
+
+// Called at the very end of the tick, once the last viz frame is computed
+func (deathmatch *DeathmatchGame) notifyGameOver() {
+	state := deathmatch.rules
+	if !state.ended || state.gameOverCalled {
+		return
+	}
+
+	state.gameOverCalled = true
+	deathmatch.cbkGameOver(state.pendingEnd.Reason)
+}
+
+func (game *DeathmatchGame) onEntityFraggedRules(e events.EntityFragged) {
+	if !game.rules.rules.lastAgentStanding {
+		return
+	}
+
+	qr := game.getEntity(e.Entity, game.respawnComponent)
+	if qr == nil {
+		return
+	}
+
+	// no respawn
+	qr.Components[game.respawnComponent].(*Respawn).eliminated = true
+}
+
+func (game *DeathmatchGame) onMatchEnded(e events.MatchEnded) {
+	game.broadcastMessage(mailboxmessages.MatchEnded{
+		Reason: e.Reason,
+		Winner: e.Winner,
+	})
+}
@@ -0,0 +1,241 @@
+package deathmatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bytearena/core/common/types/mapcontainer"
+)
+
+func TestMakeMatchRules(t *testing.T) {
+	arenaMap := &mapcontainer.MapContainer{}
+	arenaMap.Meta.Options = map[string]interface{}{
+		"rules": map[string]interface{}{
+			"fraglimit":         10.0,
+			"scorelimit":        -1.0,
+			"timelimit":         600.0,
+			"duration":          "5m",
+			"suddendeath":       true,
+			"lastagentstanding": false,
+		},
+	}
+
+	expected := matchRules{
+		fragLimit:      10,
+		timeLimit:      600,
+		wallClockLimit: 5 * time.Minute,
+		suddenDeath:    true,
+	}
+
+	if rules := makeMatchRules(arenaMap); rules != expected {
+		t.Errorf("got %+v, expected %+v", rules, expected)
+	}
+}
+
+func TestMatchRulesIsTimeUp(t *testing.T) {
+	cases := []struct {
+		name         string
+		rules        matchRules
+		elapsedTicks int
+		elapsed      time.Duration
+		expected     bool
+	}{
+		{"no limit", matchRules{}, 100000, time.Hour, false},
+		{"ticks, before", matchRules{timeLimit: 600}, 599, time.Hour, false},
+		{"ticks, reached", matchRules{timeLimit: 600}, 600, 0, true},
+		{"wall clock, before", matchRules{wallClockLimit: time.Minute}, 100000, 59 * time.Second, false},
+		{"wall clock, reached", matchRules{wallClockLimit: time.Minute}, 0, time.Minute, true},
+		{"both, first reached", matchRules{timeLimit: 600, wallClockLimit: time.Hour}, 600, time.Second, true},
+	}
+
+	for _, c := range cases {
+		if timeIsUp := c.rules.isTimeUp(c.elapsedTicks, c.elapsed); timeIsUp != c.expected {
+			t.Errorf("%s: got %v, expected %v", c.name, timeIsUp, c.expected)
+		}
+	}
+}
+
+func TestMatchRulesDecide(t *testing.T) {
+	player := func(winner string, frags int, score int, standing bool) rulesPlayer {
+		return rulesPlayer{winner: winner, frags: frags, score: score, standing: standing}
+	}
+
+	cases := []struct {
+		name          string
+		rules         matchRules
+		players       []rulesPlayer
+		timeIsUp      bool
+		inSuddenDeath bool
+		expected      rulesDecision
+	}{
+		{
+			name:     "no rules",
+			players:  []rulesPlayer{player("a", 50, 50, true), player("b", 0, 0, true)},
+			expected: rulesDecision{},
+		},
+		{
+			name:     "frag limit",
+			rules:    matchRules{fragLimit: 5},
+			players:  []rulesPlayer{player("a", 4, 10, true), player("b", 5, 3, true)},
+			expected: rulesDecision{end: true, reason: matchEndReason.FragLimit, winner: "b"},
+		},
+		{
+			name:     "frag limit, first player wins",
+			rules:    matchRules{fragLimit: 5},
+			players:  []rulesPlayer{player("a", 6, 0, true), player("b", 7, 0, true)},
+			expected: rulesDecision{end: true, reason: matchEndReason.FragLimit, winner: "a"},
+		},
+		{
+			name:     "score limit",
+			rules:    matchRules{scoreLimit: 100},
+			players:  []rulesPlayer{player("a", 0, 99, true), player("red", 0, 100, true)},
+			expected: rulesDecision{end: true, reason: matchEndReason.ScoreLimit, winner: "red"},
+		},
+		{
+			name:     "score limit, not reached",
+			rules:    matchRules{scoreLimit: 100},
+			players:  []rulesPlayer{player("a", 0, 99, true)},
+			expected: rulesDecision{},
+		},
+		{
+			name:     "time limit, leader wins",
+			rules:    matchRules{timeLimit: 600},
+			players:  []rulesPlayer{player("a", 0, 3, true), player("b", 0, 8, true)},
+			timeIsUp: true,
+			expected: rulesDecision{end: true, reason: matchEndReason.TimeLimit, winner: "b"},
+		},
+		{
+			name:     "time limit, tie without sudden death",
+			rules:    matchRules{timeLimit: 600},
+			players:  []rulesPlayer{player("a", 0, 8, true), player("b", 0, 8, true)},
+			timeIsUp: true,
+			expected: rulesDecision{end: true, reason: matchEndReason.TimeLimit, winner: ""},
+		},
+		{
+			name:     "time limit, no players",
+			rules:    matchRules{timeLimit: 600},
+			timeIsUp: true,
+			expected: rulesDecision{end: true, reason: matchEndReason.TimeLimit, winner: ""},
+		},
+		{
+			name:     "time limit, tie goes to sudden death",
+			rules:    matchRules{timeLimit: 600, suddenDeath: true},
+			players:  []rulesPlayer{player("a", 0, 8, true), player("b", 0, 2, true), player("c", 0, 8, true)},
+			timeIsUp: true,
+			expected: rulesDecision{suddenDeath: true},
+		},
+		{
+			name:     "sudden death, without a tie",
+			rules:    matchRules{timeLimit: 600, suddenDeath: true},
+			players:  []rulesPlayer{player("a", 0, 8, true), player("b", 0, 2, true)},
+			timeIsUp: true,
+			expected: rulesDecision{end: true, reason: matchEndReason.TimeLimit, winner: "a"},
+		},
+		{
+			name:          "sudden death, still tied",
+			rules:         matchRules{timeLimit: 600, suddenDeath: true},
+			players:       []rulesPlayer{player("a", 0, 8, true), player("b", 0, 8, true)},
+			timeIsUp:      true,
+			inSuddenDeath: true,
+			expected:      rulesDecision{suddenDeath: true},
+		},
+		{
+			name:          "sudden death, tie broken",
+			rules:         matchRules{timeLimit: 600, suddenDeath: true},
+			players:       []rulesPlayer{player("a", 0, 8, true), player("b", 0, 9, true)},
+			timeIsUp:      true,
+			inSuddenDeath: true,
+			expected:      rulesDecision{end: true, reason: matchEndReason.SuddenDeath, winner: "b"},
+		},
+		{
+			name:     "last agent standing",
+			rules:    matchRules{lastAgentStanding: true},
+			players:  []rulesPlayer{player("a", 0, 0, false), player("b", 2, 0, true), player("c", 0, 5, false)},
+			expected: rulesDecision{end: true, reason: matchEndReason.LastAgentStanding, winner: "b"},
+		},
+		{
+			name:     "last agent standing, none left",
+			rules:    matchRules{lastAgentStanding: true},
+			players:  []rulesPlayer{player("a", 0, 0, false), player("b", 0, 0, false)},
+			expected: rulesDecision{end: true, reason: matchEndReason.LastAgentStanding, winner: ""},
+		},
+		{
+			name:     "last agent standing, several left",
+			rules:    matchRules{lastAgentStanding: true},
+			players:  []rulesPlayer{player("a", 0, 0, true), player("b", 0, 0, false), player("c", 0, 0, true)},
+			expected: rulesDecision{},
+		},
+		{
+			name:     "last agent standing, alone in the match",
+			rules:    matchRules{lastAgentStanding: true},
+			players:  []rulesPlayer{player("a", 0, 0, true)},
+			expected: rulesDecision{},
+		},
+		{
+			name:     "teams, frag limit",
+			rules:    matchRules{fragLimit: 5},
+			players:  []rulesPlayer{player("red", 3, 0, true), player("blue", 4, 0, true), player("red", 2, 0, true)},
+			expected: rulesDecision{end: true, reason: matchEndReason.FragLimit, winner: "red"},
+		},
+		{
+			name:     "teams, time limit, leader wins",
+			rules:    matchRules{timeLimit: 600},
+			players:  []rulesPlayer{player("red", 0, 2, true), player("blue", 0, 1, true), player("red", 0, 2, true), player("blue", 0, 1, true)},
+			timeIsUp: true,
+			expected: rulesDecision{end: true, reason: matchEndReason.TimeLimit, winner: "red"},
+		},
+		{
+			name:     "teams, sudden death, without a tie",
+			rules:    matchRules{timeLimit: 600, suddenDeath: true},
+			players:  []rulesPlayer{player("red", 0, 2, true), player("blue", 0, 1, true), player("red", 0, 2, true), player("blue", 0, 1, true)},
+			timeIsUp: true,
+			expected: rulesDecision{end: true, reason: matchEndReason.TimeLimit, winner: "red"},
+		},
+		{
+			name:     "teams, tie goes to sudden death",
+			rules:    matchRules{timeLimit: 600, suddenDeath: true},
+			players:  []rulesPlayer{player("red", 0, 2, true), player("blue", 0, 2, true), player("red", 0, 2, true)},
+			timeIsUp: true,
+			expected: rulesDecision{suddenDeath: true},
+		},
+		{
+			name:          "teams, sudden death, tie broken",
+			rules:         matchRules{timeLimit: 600, suddenDeath: true},
+			players:       []rulesPlayer{player("red", 0, 2, true), player("blue", 0, 3, true), player("red", 0, 2, true), player("blue", 0, 3, true)},
+			timeIsUp:      true,
+			inSuddenDeath: true,
+			expected:      rulesDecision{end: true, reason: matchEndReason.SuddenDeath, winner: "blue"},
+		},
+		{
+			name:     "teams, last team standing",
+			rules:    matchRules{lastAgentStanding: true},
+			players:  []rulesPlayer{player("red", 0, 0, true), player("blue", 0, 0, false), player("red", 0, 0, true), player("blue", 0, 0, false)},
+			expected: rulesDecision{end: true, reason: matchEndReason.LastAgentStanding, winner: "red"},
+		},
+		{
+			name:     "teams, several teams standing",
+			rules:    matchRules{lastAgentStanding: true},
+			players:  []rulesPlayer{player("red", 0, 0, false), player("blue", 0, 0, false), player("red", 0, 0, true), player("blue", 0, 0, true)},
+			expected: rulesDecision{},
+		},
+		{
+			name:     "teams, alone in the match",
+			rules:    matchRules{lastAgentStanding: true},
+			players:  []rulesPlayer{player("red", 0, 0, true), player("red", 0, 0, true)},
+			expected: rulesDecision{},
+		},
+		{
+			name:     "limits before time",
+			rules:    matchRules{fragLimit: 5, timeLimit: 600},
+			players:  []rulesPlayer{player("a", 0, 9, true), player("b", 5, 0, true)},
+			timeIsUp: true,
+			expected: rulesDecision{end: true, reason: matchEndReason.FragLimit, winner: "b"},
+		},
+	}
+
+	for _, c := range cases {
+		if decision := c.rules.decide(c.players, c.timeIsUp, c.inSuddenDeath); decision != c.expected {
+			t.Errorf("%s: got %+v, expected %+v", c.name, decision, c.expected)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package deathmatch
 
 import (
+	"github.com/bytearena/ecs"
+
 	"github.com/bytearena/core/game/deathmatch/mailboxmessages"
 )
 
@@ -13,6 +15,10 @@ func systemScore(deathmatch *DeathmatchGame) {
 
 		playerAspect.Score = calculatePlayerScore(playerAspect)
 
+		if modeScore, ok := getModeScore(deathmatch, result.Entity.GetID()); ok {
+			playerAspect.Score = modeScore
+		}
+
 		if playerAspect.Score != oldScore {
 			mailboxAspect := result.Components[deathmatch.mailboxComponent].(*Mailbox)
 
@@ -29,6 +35,25 @@ func calculatePlayerScore(p *Player) (score int) {
 	return score
 }
 
+// Game modes score by team (or by agent when not playing in teams)
+func getModeScore(deathmatch *DeathmatchGame, entityID ecs.EntityID) (int, bool) {
+
+	if deathmatch.ctf != nil {
+		// captures of the team
+		if teamQr := deathmatch.getEntity(entityID, deathmatch.teamComponent); teamQr != nil {
+			teamAspect := teamQr.Components[deathmatch.teamComponent].(*Team)
+			return deathmatch.ctf.scores[teamAspect.GetName()], true
+		}
+	}
+
+	if deathmatch.koth != nil {
+		// ticks of sole control of the zone
+		return deathmatch.koth.scores[deathmatch.getZoneHolderName(entityID)], true
+	}
+
+	return 0, false
+}
+
 func sendScoreToAgent(mailbox *Mailbox, player *Player) {
 	mailbox.PushMessage(mailboxmessages.Score{
 		Value: player.Score,
@@ -1,11 +1,21 @@
 package deathmatch
 
 import (
+	"math"
+
+	"github.com/bytearena/box2d"
+	"github.com/bytearena/ecs"
+
+	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/utils/space"
 	"github.com/bytearena/core/common/utils/trigo"
+	"github.com/bytearena/core/common/utils/vector"
 )
 
 func systemShooting(deathmatch *DeathmatchGame) {
 
+	deathmatch.beams = make([]beam, 0)
+
 	for _, entityresult := range deathmatch.shootingView.Get() {
 
 		shootingAspect := entityresult.Components[deathmatch.shootingComponent].(*Shooting)
@@ -21,21 +31,27 @@ func systemShooting(deathmatch *DeathmatchGame) {
 			continue
 		}
 
+		weapon := shootingAspect.GetWeapon(shots[0].weapon)
+		if weapon == nil {
+			// invalid shot, unknown weapon
+			continue
+		}
+
 		// //
 		// // Levels consumption
 		// //
 
-		if deathmatch.ticknum-shootingAspect.LastShot <= shootingAspect.ShootCooldown {
+		if deathmatch.ticknum-shootingAspect.LastShot <= shootingAspect.LastShotCooldown {
 			// invalid shot, cooldown not over
 			continue
 		}
 
-		if shootingAspect.ShootEnergy < shootingAspect.ShootCost {
+		if shootingAspect.ShootEnergy < weapon.ShootCost {
 			// invalid shot, not enough energy
 			continue
 		}
 
-		aiming := shots[0]
+		aiming := shots[0].aiming
 		entity := entityresult.Entity
 		if aiming.IsNull() {
 			// 0-mag aiming vector disabled (no mines !)
@@ -43,21 +59,90 @@ func systemShooting(deathmatch *DeathmatchGame) {
 		}
 
 		shootingAspect.LastShot = deathmatch.ticknum
-		shootingAspect.ShootEnergy -= shootingAspect.ShootCost
+		shootingAspect.LastShotCooldown = weapon.ShootCooldown
+		shootingAspect.ShootEnergy -= weapon.ShootCost
+
+		orientation := physicalAspect.GetOrientation()
+
+		// on passe le vecteur de visée d'un angle relatif à un angle absolu
+		direction := trigo.
+			LocalAngleToAbsoluteAngleVec(orientation, aiming, nil). // TODO: replace nil here by an actual angle constraint
+			SetMag(1)                                               // Unit vector for aiming
+
+		if weapon.IsHitscan() {
+			shootHitscan(deathmatch, entity.GetID(), physicalAspect.GetPosition(), direction, weapon)
+			continue
+		}
 
 		///////////////////////////////////////////////////////////////////////////
 		///////////////////////////////////////////////////////////////////////////
-		// Make physical body for projectile
+		// Make physical bodies for projectiles
 		///////////////////////////////////////////////////////////////////////////
 		///////////////////////////////////////////////////////////////////////////
 
-		orientation := physicalAspect.GetOrientation()
+		if weapon.Pellets <= 1 {
+			deathmatch.NewEntityBallisticProjectile(entity.GetID(), space.MakeAgentVector2FromVector2(physicalAspect.GetPosition()), direction, weapon)
+			continue
+		}
 
-		// on passe le vecteur de visée d'un angle relatif à un angle absolu
-		velocity := trigo.
-			LocalAngleToAbsoluteAngleVec(orientation, aiming, nil). // TODO: replace nil here by an actual angle constraint
-			SetMag(1)                                               // Unit vector for aiming
+		// pellets are evenly distributed over the spread, centered on the aiming direction
+		step := weapon.Spread / float64(weapon.Pellets-1)
+		for i := 0; i < weapon.Pellets; i++ {
+			pelletDirection := direction.SetAngle(direction.Angle() - weapon.Spread/2 + float64(i)*step)
+			deathmatch.NewEntityBallisticProjectile(entity.GetID(), space.MakeAgentVector2FromVector2(physicalAspect.GetPosition()), pelletDirection, weapon)
+		}
+	}
+}
+
+func shootHitscan(deathmatch *DeathmatchGame, shooterID ecs.EntityID, position vector.Vector2, direction vector.Vector2, weapon *Weapon) {
+
+	from := deathmatch.spaces.AgentToPhysical(space.MakeAgentVector2FromVector2(position))
+	to := deathmatch.spaces.AgentToPhysical(space.MakeAgentVector2FromVector2(position.Add(direction.SetMag(weapon.ProjectileRange))))
+
+	closestFraction := math.MaxFloat64
+	closestPoint := to
+	var closestDescriptor *commontypes.PhysicalBodyDescriptor
+
+	deathmatch.PhysicalWorld.RayCast(func(fixture *box2d.B2Fixture, point box2d.B2Vec2, normal box2d.B2Vec2, fraction float64) float64 {
+
+		if fixture.IsSensor() {
+			return -1 // ignore this fixture
+		}
 
-		deathmatch.NewEntityBallisticProjectile(entity.GetID(), physicalAspect.GetPosition(), velocity)
+		descriptor, ok := fixture.GetBody().GetUserData().(commontypes.PhysicalBodyDescriptor)
+		if !ok || descriptor.ID == shooterID {
+			return -1 // ignore this fixture
+		}
+
+		if descriptor.Type != commontypes.PhysicalBodyDescriptorType.Agent && descriptor.Type != commontypes.PhysicalBodyDescriptorType.Obstacle {
+			// beams go through projectiles and grounds
+			return -1
+		}
+
+		if fraction < closestFraction {
+			closestFraction = fraction
+			closestPoint = space.PhysicalFromB2Vec2(point)
+			closestDescriptor = &descriptor
+		}
+
+		return fraction // clip the ray to this point
+	}, from.ToB2Vec2(), to.ToB2Vec2())
+
+	deathmatch.beams = append(deathmatch.beams, beam{
+		from: from,
+		to:   closestPoint,
+	})
+
+	if closestDescriptor == nil {
+		return
 	}
+
+	// entities without health (static obstacles) are ignored by systemHealth
+
+	deathmatch.impacts = append(deathmatch.impacts, impact{
+		entityID:   closestDescriptor.ID,
+		impactorID: shooterID,
+		damage:     weapon.ProjectileDamage,
+		comingFrom: direction.Angle(),
+	})
 }
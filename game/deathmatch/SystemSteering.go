@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/bytearena/core/common/utils/trigo"
+	"github.com/bytearena/core/common/utils/vector"
 )
 
 func systemSteering(deathmatch *DeathmatchGame) {
@@ -13,6 +14,10 @@ func systemSteering(deathmatch *DeathmatchGame) {
 
 		steers := steeringAspect.PopPendingSteers()
 		if len(steers) == 0 {
+			if dragForce := physicalAspect.GetDragForce(); dragForce > 0 {
+				physicalAspect.SetVelocity(applyDrag(physicalAspect.GetVelocity(), dragForce))
+			}
+
 			continue
 		}
 
@@ -42,3 +47,13 @@ func systemSteering(deathmatch *DeathmatchGame) {
 		physicalAspect.SetVelocity(abssteering)
 	}
 }
+
+// Agents that do not steer slow down, until they stop
+func applyDrag(velocity vector.Vector2, dragForce float64) vector.Vector2 {
+	speed := velocity.Mag()
+	if speed <= dragForce {
+		return vector.MakeNullVector2()
+	}
+
+	return velocity.SetMag(speed - dragForce)
+}
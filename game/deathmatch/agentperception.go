@@ -15,16 +15,53 @@ type agentPerception struct {
 	ShootEnergy   float64                           `json:"shootenergy"`
 	ShootCooldown int                               `json:"shootcooldown"`
 	Messages      []mailboxMessagePerceptionWrapper `json:"messages"`
+
+	Team  string                `json:"team,omitempty"`  // team games only
+	Flags []agentPerceptionFlag `json:"flags,omitempty"` // ctf only
+	Zone  *agentPerceptionZone  `json:"zone,omitempty"`  // koth only
+
+	Rangefinder []agentPerceptionRay   `json:"rangefinder,omitempty"` // agents equipped with a rangefinder only
+	Hearing     []agentPerceptionSound `json:"hearing,omitempty"`     // agents equipped with hearing only
+}
+
+type agentPerceptionRay struct {
+	Angle    float64 `json:"angle"`    // relative to the heading, in radian
+	Distance float64 `json:"distance"` // to the first hit; the range of the rangefinder if nothing was hit
+	Tag      string  `json:"tag"`      // tag of the hit item, as in vision; empty if nothing was hit
+}
+
+type agentPerceptionSound struct {
+	Kind      string  `json:"kind"`      // shot, impact or frag
+	Direction float64 `json:"direction"` // relative to the heading, in radian
+	Distance  float64 `json:"distance"`
+}
+
+type agentPerceptionZone struct {
+	Holder    string `json:"holder"` // team or agent holding the zone; empty if none
+	Contested bool   `json:"contested"`
+	Inside    bool   `json:"inside"`
+	Score     int    `json:"score"`    // ticks of sole control of the team (or agent)
+	ScoreCap  int    `json:"scorecap"` // the match ends when a score reaches the cap
+}
+
+type agentPerceptionFlag struct {
+	Team    string `json:"team"`
+	Carrier string `json:"carrier"` // id of the carrying agent; empty if not carried
+	AtHome  bool   `json:"athome"`
 }
 
 var agentPerceptionVisionItemTag = struct {
 	Agent      string
 	Obstacle   string
 	Projectile string
+	Pickup     string
+	Flag       string
 }{
 	Agent:      "agent",
 	Obstacle:   "obstacle",
 	Projectile: "projectile",
+	Pickup:     "pickup",
+	Flag:       "flag",
 }
 
 type agentPerceptionVisionItem struct {
@@ -33,6 +70,7 @@ type agentPerceptionVisionItem struct {
 	Center     vector.Vector2 `json:"center"`
 	FarEdge    vector.Vector2 `json:"faredge"`
 	Velocity   vector.Vector2 `json:"velocity"`
+	Visibility float64        `json:"visibility"` // visible part of the item, from 0 (hidden) to 1 (not occluded)
 	EntityID   ecs.EntityID   `json:"-"`
 	SegmentNum int            `json:"-"`
 }
@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch(in *jlexer.Lexer, out *mailboxMessagePerceptionWrapper) {
+func easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch(in *jlexer.Lexer, out *mailboxMessagePerceptionWrapper) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -56,27 +56,25 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch(out *jwriter.Writer, in mailboxMessagePerceptionWrapper) {
+func easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch(out *jwriter.Writer, in mailboxMessagePerceptionWrapper) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"subject\":")
-	out.String(string(in.Subject))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"body\":")
-	if m, ok := in.Body.(easyjson.Marshaler); ok {
-		m.MarshalEasyJSON(out)
-	} else if m, ok := in.Body.(json.Marshaler); ok {
-		out.Raw(m.MarshalJSON())
-	} else {
-		out.Raw(json.Marshal(in.Body))
+	{
+		const prefix string = ",\"subject\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Subject))
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		if m, ok := in.Body.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(out)
+		} else if m, ok := in.Body.(json.Marshaler); ok {
+			out.Raw(m.MarshalJSON())
+		} else {
+			out.Raw(json.Marshal(in.Body))
+		}
 	}
 	out.RawByte('}')
 }
@@ -84,27 +82,121 @@ func easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch(out *jwriter
 // MarshalJSON supports json.Marshaler interface
 func (v mailboxMessagePerceptionWrapper) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch(&w, v)
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v mailboxMessagePerceptionWrapper) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch(w, v)
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *mailboxMessagePerceptionWrapper) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch(&r, v)
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *mailboxMessagePerceptionWrapper) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch(l, v)
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch(l, v)
 }
-func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch1(in *jlexer.Lexer, out *agentPerceptionVisionItem) {
+func easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch1(in *jlexer.Lexer, out *agentPerceptionZone) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "holder":
+			out.Holder = string(in.String())
+		case "contested":
+			out.Contested = bool(in.Bool())
+		case "inside":
+			out.Inside = bool(in.Bool())
+		case "score":
+			out.Score = int(in.Int())
+		case "scorecap":
+			out.ScoreCap = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch1(out *jwriter.Writer, in agentPerceptionZone) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"holder\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Holder))
+	}
+	{
+		const prefix string = ",\"contested\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Contested))
+	}
+	{
+		const prefix string = ",\"inside\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Inside))
+	}
+	{
+		const prefix string = ",\"score\":"
+		out.RawString(prefix)
+		out.Int(int(in.Score))
+	}
+	{
+		const prefix string = ",\"scorecap\":"
+		out.RawString(prefix)
+		out.Int(int(in.ScoreCap))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v agentPerceptionZone) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v agentPerceptionZone) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *agentPerceptionZone) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *agentPerceptionZone) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch1(l, v)
+}
+func easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch2(in *jlexer.Lexer, out *agentPerceptionVisionItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -133,7 +225,7 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch1(in *jlexer.
 				v1 := 0
 				for !in.IsDelim(']') {
 					if v1 < 2 {
-						out.NearEdge[v1] = float64(in.Float64())
+						(out.NearEdge)[v1] = float64(in.Float64())
 						v1++
 					} else {
 						in.SkipRecursive()
@@ -150,7 +242,7 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch1(in *jlexer.
 				v2 := 0
 				for !in.IsDelim(']') {
 					if v2 < 2 {
-						out.Center[v2] = float64(in.Float64())
+						(out.Center)[v2] = float64(in.Float64())
 						v2++
 					} else {
 						in.SkipRecursive()
@@ -167,7 +259,7 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch1(in *jlexer.
 				v3 := 0
 				for !in.IsDelim(']') {
 					if v3 < 2 {
-						out.FarEdge[v3] = float64(in.Float64())
+						(out.FarEdge)[v3] = float64(in.Float64())
 						v3++
 					} else {
 						in.SkipRecursive()
@@ -184,7 +276,7 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch1(in *jlexer.
 				v4 := 0
 				for !in.IsDelim(']') {
 					if v4 < 2 {
-						out.Velocity[v4] = float64(in.Float64())
+						(out.Velocity)[v4] = float64(in.Float64())
 						v4++
 					} else {
 						in.SkipRecursive()
@@ -193,6 +285,8 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch1(in *jlexer.
 				}
 				in.Delim(']')
 			}
+		case "visibility":
+			out.Visibility = float64(in.Float64())
 		default:
 			in.SkipRecursive()
 		}
@@ -203,95 +297,335 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch1(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch1(out *jwriter.Writer, in agentPerceptionVisionItem) {
+func easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch2(out *jwriter.Writer, in agentPerceptionVisionItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"tag\":")
-	out.String(string(in.Tag))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"nearedge\":")
-	out.RawByte('[')
-	for v5 := range in.NearEdge {
-		if v5 > 0 {
-			out.RawByte(',')
-		}
-		out.Float64(float64(in.NearEdge[v5]))
-	}
-	out.RawByte(']')
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"center\":")
-	out.RawByte('[')
-	for v6 := range in.Center {
-		if v6 > 0 {
-			out.RawByte(',')
-		}
-		out.Float64(float64(in.Center[v6]))
-	}
-	out.RawByte(']')
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"faredge\":")
-	out.RawByte('[')
-	for v7 := range in.FarEdge {
-		if v7 > 0 {
-			out.RawByte(',')
-		}
-		out.Float64(float64(in.FarEdge[v7]))
-	}
-	out.RawByte(']')
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"velocity\":")
-	out.RawByte('[')
-	for v8 := range in.Velocity {
-		if v8 > 0 {
-			out.RawByte(',')
-		}
-		out.Float64(float64(in.Velocity[v8]))
-	}
-	out.RawByte(']')
+	{
+		const prefix string = ",\"tag\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Tag))
+	}
+	{
+		const prefix string = ",\"nearedge\":"
+		out.RawString(prefix)
+		out.RawByte('[')
+		for v5 := range in.NearEdge {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			out.Float64(float64((in.NearEdge)[v5]))
+		}
+		out.RawByte(']')
+	}
+	{
+		const prefix string = ",\"center\":"
+		out.RawString(prefix)
+		out.RawByte('[')
+		for v6 := range in.Center {
+			if v6 > 0 {
+				out.RawByte(',')
+			}
+			out.Float64(float64((in.Center)[v6]))
+		}
+		out.RawByte(']')
+	}
+	{
+		const prefix string = ",\"faredge\":"
+		out.RawString(prefix)
+		out.RawByte('[')
+		for v7 := range in.FarEdge {
+			if v7 > 0 {
+				out.RawByte(',')
+			}
+			out.Float64(float64((in.FarEdge)[v7]))
+		}
+		out.RawByte(']')
+	}
+	{
+		const prefix string = ",\"velocity\":"
+		out.RawString(prefix)
+		out.RawByte('[')
+		for v8 := range in.Velocity {
+			if v8 > 0 {
+				out.RawByte(',')
+			}
+			out.Float64(float64((in.Velocity)[v8]))
+		}
+		out.RawByte(']')
+	}
+	{
+		const prefix string = ",\"visibility\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Visibility))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v agentPerceptionVisionItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch1(&w, v)
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v agentPerceptionVisionItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch1(w, v)
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *agentPerceptionVisionItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch1(&r, v)
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *agentPerceptionVisionItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch1(l, v)
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch2(l, v)
+}
+func easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch3(in *jlexer.Lexer, out *agentPerceptionSound) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "kind":
+			out.Kind = string(in.String())
+		case "direction":
+			out.Direction = float64(in.Float64())
+		case "distance":
+			out.Distance = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch3(out *jwriter.Writer, in agentPerceptionSound) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"direction\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Direction))
+	}
+	{
+		const prefix string = ",\"distance\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Distance))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v agentPerceptionSound) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v agentPerceptionSound) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch3(w, v)
 }
-func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch2(in *jlexer.Lexer, out *agentPerception) {
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *agentPerceptionSound) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *agentPerceptionSound) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch3(l, v)
+}
+func easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch4(in *jlexer.Lexer, out *agentPerceptionRay) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "angle":
+			out.Angle = float64(in.Float64())
+		case "distance":
+			out.Distance = float64(in.Float64())
+		case "tag":
+			out.Tag = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch4(out *jwriter.Writer, in agentPerceptionRay) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"angle\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.Angle))
+	}
+	{
+		const prefix string = ",\"distance\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Distance))
+	}
+	{
+		const prefix string = ",\"tag\":"
+		out.RawString(prefix)
+		out.String(string(in.Tag))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v agentPerceptionRay) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v agentPerceptionRay) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *agentPerceptionRay) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *agentPerceptionRay) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch4(l, v)
+}
+func easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch5(in *jlexer.Lexer, out *agentPerceptionFlag) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "team":
+			out.Team = string(in.String())
+		case "carrier":
+			out.Carrier = string(in.String())
+		case "athome":
+			out.AtHome = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch5(out *jwriter.Writer, in agentPerceptionFlag) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"team\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Team))
+	}
+	{
+		const prefix string = ",\"carrier\":"
+		out.RawString(prefix)
+		out.String(string(in.Carrier))
+	}
+	{
+		const prefix string = ",\"athome\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.AtHome))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v agentPerceptionFlag) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v agentPerceptionFlag) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *agentPerceptionFlag) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *agentPerceptionFlag) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch5(l, v)
+}
+func easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch6(in *jlexer.Lexer, out *agentPerception) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -322,7 +656,7 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch2(in *jlexer.
 				v9 := 0
 				for !in.IsDelim(']') {
 					if v9 < 2 {
-						out.Velocity[v9] = float64(in.Float64())
+						(out.Velocity)[v9] = float64(in.Float64())
 						v9++
 					} else {
 						in.SkipRecursive()
@@ -341,7 +675,7 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch2(in *jlexer.
 				in.Delim('[')
 				if out.Vision == nil {
 					if !in.IsDelim(']') {
-						out.Vision = make([]agentPerceptionVisionItem, 0, 1)
+						out.Vision = make([]agentPerceptionVisionItem, 0, 0)
 					} else {
 						out.Vision = []agentPerceptionVisionItem{}
 					}
@@ -383,6 +717,87 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch2(in *jlexer.
 				}
 				in.Delim(']')
 			}
+		case "team":
+			out.Team = string(in.String())
+		case "flags":
+			if in.IsNull() {
+				in.Skip()
+				out.Flags = nil
+			} else {
+				in.Delim('[')
+				if out.Flags == nil {
+					if !in.IsDelim(']') {
+						out.Flags = make([]agentPerceptionFlag, 0, 1)
+					} else {
+						out.Flags = []agentPerceptionFlag{}
+					}
+				} else {
+					out.Flags = (out.Flags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v12 agentPerceptionFlag
+					(v12).UnmarshalEasyJSON(in)
+					out.Flags = append(out.Flags, v12)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "zone":
+			if in.IsNull() {
+				in.Skip()
+				out.Zone = nil
+			} else {
+				if out.Zone == nil {
+					out.Zone = new(agentPerceptionZone)
+				}
+				(*out.Zone).UnmarshalEasyJSON(in)
+			}
+		case "rangefinder":
+			if in.IsNull() {
+				in.Skip()
+				out.Rangefinder = nil
+			} else {
+				in.Delim('[')
+				if out.Rangefinder == nil {
+					if !in.IsDelim(']') {
+						out.Rangefinder = make([]agentPerceptionRay, 0, 2)
+					} else {
+						out.Rangefinder = []agentPerceptionRay{}
+					}
+				} else {
+					out.Rangefinder = (out.Rangefinder)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 agentPerceptionRay
+					(v13).UnmarshalEasyJSON(in)
+					out.Rangefinder = append(out.Rangefinder, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "hearing":
+			if in.IsNull() {
+				in.Skip()
+				out.Hearing = nil
+			} else {
+				in.Delim('[')
+				if out.Hearing == nil {
+					if !in.IsDelim(']') {
+						out.Hearing = make([]agentPerceptionSound, 0, 2)
+					} else {
+						out.Hearing = []agentPerceptionSound{}
+					}
+				} else {
+					out.Hearing = (out.Hearing)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v14 agentPerceptionSound
+					(v14).UnmarshalEasyJSON(in)
+					out.Hearing = append(out.Hearing, v14)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -393,86 +808,130 @@ func easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch2(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch2(out *jwriter.Writer, in agentPerception) {
+func easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch6(out *jwriter.Writer, in agentPerception) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"score\":")
-	out.Int(int(in.Score))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"energy\":")
-	out.Float64(float64(in.Energy))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"velocity\":")
-	out.RawByte('[')
-	for v12 := range in.Velocity {
-		if v12 > 0 {
-			out.RawByte(',')
-		}
-		out.Float64(float64(in.Velocity[v12]))
-	}
-	out.RawByte(']')
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"azimuth\":")
-	out.Float64(float64(in.Azimuth))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"vision\":")
-	if in.Vision == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-		out.RawString("null")
-	} else {
+	{
+		const prefix string = ",\"score\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Score))
+	}
+	{
+		const prefix string = ",\"energy\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Energy))
+	}
+	{
+		const prefix string = ",\"velocity\":"
+		out.RawString(prefix)
 		out.RawByte('[')
-		for v13, v14 := range in.Vision {
-			if v13 > 0 {
+		for v15 := range in.Velocity {
+			if v15 > 0 {
 				out.RawByte(',')
 			}
-			(v14).MarshalEasyJSON(out)
+			out.Float64(float64((in.Velocity)[v15]))
 		}
 		out.RawByte(']')
 	}
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"shootenergy\":")
-	out.Float64(float64(in.ShootEnergy))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"shootcooldown\":")
-	out.Int(int(in.ShootCooldown))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"messages\":")
-	if in.Messages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-		out.RawString("null")
-	} else {
-		out.RawByte('[')
-		for v15, v16 := range in.Messages {
-			if v15 > 0 {
-				out.RawByte(',')
+	{
+		const prefix string = ",\"azimuth\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Azimuth))
+	}
+	{
+		const prefix string = ",\"vision\":"
+		out.RawString(prefix)
+		if in.Vision == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v16, v17 := range in.Vision {
+				if v16 > 0 {
+					out.RawByte(',')
+				}
+				(v17).MarshalEasyJSON(out)
 			}
-			(v16).MarshalEasyJSON(out)
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"shootenergy\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ShootEnergy))
+	}
+	{
+		const prefix string = ",\"shootcooldown\":"
+		out.RawString(prefix)
+		out.Int(int(in.ShootCooldown))
+	}
+	{
+		const prefix string = ",\"messages\":"
+		out.RawString(prefix)
+		if in.Messages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v18, v19 := range in.Messages {
+				if v18 > 0 {
+					out.RawByte(',')
+				}
+				(v19).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Team != "" {
+		const prefix string = ",\"team\":"
+		out.RawString(prefix)
+		out.String(string(in.Team))
+	}
+	if len(in.Flags) != 0 {
+		const prefix string = ",\"flags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v20, v21 := range in.Flags {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				(v21).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Zone != nil {
+		const prefix string = ",\"zone\":"
+		out.RawString(prefix)
+		(*in.Zone).MarshalEasyJSON(out)
+	}
+	if len(in.Rangefinder) != 0 {
+		const prefix string = ",\"rangefinder\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v22, v23 := range in.Rangefinder {
+				if v22 > 0 {
+					out.RawByte(',')
+				}
+				(v23).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Hearing) != 0 {
+		const prefix string = ",\"hearing\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v24, v25 := range in.Hearing {
+				if v24 > 0 {
+					out.RawByte(',')
+				}
+				(v25).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
 		}
-		out.RawByte(']')
 	}
 	out.RawByte('}')
 }
@@ -480,23 +939,23 @@ func easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch2(out *jwrite
 // MarshalJSON supports json.Marshaler interface
 func (v agentPerception) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch2(&w, v)
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v agentPerception) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonA8da870EncodeGithubComBytearenaBytearenaGameDeathmatch2(w, v)
+	easyjsonA8da870EncodeGithubComBytearenaCoreGameDeathmatch6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *agentPerception) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch2(&r, v)
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *agentPerception) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonA8da870DecodeGithubComBytearenaBytearenaGameDeathmatch2(l, v)
+	easyjsonA8da870DecodeGithubComBytearenaCoreGameDeathmatch6(l, v)
 }
@@ -16,12 +16,31 @@ type agentSpecs struct {
 	// Shoot
 	MaxShootEnergy    float64 `json:"maxshootenergy"`
 	ShootRecoveryRate float64 `json:"shootrecoveryrate"`
+	DefaultWeapon     string  `json:"defaultweapon"` // weapon used when shoot does not name one
 
 	Gear map[string]agentGearSpecs `json:"gear"`
+
+	// Say
+	MaxSayPayloadSize  int     `json:"maxsaypayloadsize"`  // in bytes, for one message
+	MaxSayBytesPerTick int     `json:"maxsaybytespertick"` // in bytes, for all the messages of a tick
+	MaxSayRadius       float64 `json:"maxsayradius"`       // range of messages said around, in m
+
+	// Sensors, besides vision; by kind
+	Sensors map[string]interface{} `json:"sensors"`
+}
+
+type rangefinderSpecs struct {
+	Rays  int         `json:"rays"`  // number of rays, evenly spread over the angle
+	Angle types.Angle `json:"angle"` // angle covered by the rays, centered on the heading
+	Range float64     `json:"range"` // range of the rays, in m
+}
+
+type hearingSpecs struct {
+	Radius float64 `json:"radius"` // shots and impacts are heard within this radius, in m
 }
 
 type agentGearSpecs struct {
-	Genre string      `json:"genre"` // Gun
+	Genre string      `json:"genre"` // weapon
 	Kind  string      `json:"kind"`
 	Specs interface{} `json:"specs"`
 }
@@ -33,3 +52,30 @@ type gunSpecs struct {
 	ProjectileDamage float64 `json:"projectiledamage"` // damage inflicted when projectile hits
 	ProjectileRange  float64 `json:"projectilerange"`  // range of projectile, in m
 }
+
+type shotgunSpecs struct {
+	ShootCost        float64 `json:"shootcost"`        // energy cost of 1 shot
+	ShootCooldown    int     `json:"shootcooldown"`    // time to wait between shots (in ticks)
+	ProjectileSpeed  float64 `json:"projectilespeed"`  // projectile speed (in m/tick)
+	ProjectileDamage float64 `json:"projectiledamage"` // damage inflicted when one pellet hits
+	ProjectileRange  float64 `json:"projectilerange"`  // range of projectile, in m
+	Pellets          int     `json:"pellets"`          // number of pellets per shot
+	Spread           float64 `json:"spread"`           // angle covered by the pellets, in radian
+}
+
+type rocketSpecs struct {
+	ShootCost        float64 `json:"shootcost"`        // energy cost of 1 rocket
+	ShootCooldown    int     `json:"shootcooldown"`    // time to wait between shots (in ticks)
+	ProjectileSpeed  float64 `json:"projectilespeed"`  // projectile speed (in m/tick)
+	ProjectileDamage float64 `json:"projectiledamage"` // damage inflicted when the rocket hits
+	ProjectileRange  float64 `json:"projectilerange"`  // range of projectile, in m
+	SplashRadius     float64 `json:"splashradius"`     // radius of the explosion, in m
+	SplashDamage     float64 `json:"splashdamage"`     // damage at the center of the explosion
+}
+
+type laserSpecs struct {
+	ShootCost     float64 `json:"shootcost"`     // energy cost of 1 shot
+	ShootCooldown int     `json:"shootcooldown"` // time to wait between shots (in ticks)
+	Damage        float64 `json:"damage"`        // damage inflicted when the beam hits
+	Range         float64 `json:"range"`         // range of the beam, in m
+}
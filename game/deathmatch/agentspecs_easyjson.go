@@ -18,7 +18,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch(in *jlexer.Lexer, out *gunSpecs) {
+func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch(in *jlexer.Lexer, out *shotgunSpecs) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -47,6 +47,10 @@ func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch(in *jlexer.Lexer
 			out.ProjectileDamage = float64(in.Float64())
 		case "projectilerange":
 			out.ProjectileRange = float64(in.Float64())
+		case "pellets":
+			out.Pellets = int(in.Int())
+		case "spread":
+			out.Spread = float64(in.Float64())
 		default:
 			in.SkipRecursive()
 		}
@@ -57,67 +61,507 @@ func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch(in *jlexer.Lexer
 		in.Consumed()
 	}
 }
-func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch(out *jwriter.Writer, in gunSpecs) {
+func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch(out *jwriter.Writer, in shotgunSpecs) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"shootcost\":")
-	out.Float64(float64(in.ShootCost))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"shootcooldown\":")
-	out.Int(int(in.ShootCooldown))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"projectilespeed\":")
-	out.Float64(float64(in.ProjectileSpeed))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"projectiledamage\":")
-	out.Float64(float64(in.ProjectileDamage))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"projectilerange\":")
-	out.Float64(float64(in.ProjectileRange))
+	{
+		const prefix string = ",\"shootcost\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.ShootCost))
+	}
+	{
+		const prefix string = ",\"shootcooldown\":"
+		out.RawString(prefix)
+		out.Int(int(in.ShootCooldown))
+	}
+	{
+		const prefix string = ",\"projectilespeed\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ProjectileSpeed))
+	}
+	{
+		const prefix string = ",\"projectiledamage\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ProjectileDamage))
+	}
+	{
+		const prefix string = ",\"projectilerange\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ProjectileRange))
+	}
+	{
+		const prefix string = ",\"pellets\":"
+		out.RawString(prefix)
+		out.Int(int(in.Pellets))
+	}
+	{
+		const prefix string = ",\"spread\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Spread))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v gunSpecs) MarshalJSON() ([]byte, error) {
+func (v shotgunSpecs) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v gunSpecs) MarshalEasyJSON(w *jwriter.Writer) {
+func (v shotgunSpecs) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *gunSpecs) UnmarshalJSON(data []byte) error {
+func (v *shotgunSpecs) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *gunSpecs) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *shotgunSpecs) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch(l, v)
 }
-func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch1(in *jlexer.Lexer, out *agentSpecs) {
+func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch1(in *jlexer.Lexer, out *rocketSpecs) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "shootcost":
+			out.ShootCost = float64(in.Float64())
+		case "shootcooldown":
+			out.ShootCooldown = int(in.Int())
+		case "projectilespeed":
+			out.ProjectileSpeed = float64(in.Float64())
+		case "projectiledamage":
+			out.ProjectileDamage = float64(in.Float64())
+		case "projectilerange":
+			out.ProjectileRange = float64(in.Float64())
+		case "splashradius":
+			out.SplashRadius = float64(in.Float64())
+		case "splashdamage":
+			out.SplashDamage = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch1(out *jwriter.Writer, in rocketSpecs) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"shootcost\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.ShootCost))
+	}
+	{
+		const prefix string = ",\"shootcooldown\":"
+		out.RawString(prefix)
+		out.Int(int(in.ShootCooldown))
+	}
+	{
+		const prefix string = ",\"projectilespeed\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ProjectileSpeed))
+	}
+	{
+		const prefix string = ",\"projectiledamage\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ProjectileDamage))
+	}
+	{
+		const prefix string = ",\"projectilerange\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ProjectileRange))
+	}
+	{
+		const prefix string = ",\"splashradius\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.SplashRadius))
+	}
+	{
+		const prefix string = ",\"splashdamage\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.SplashDamage))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v rocketSpecs) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v rocketSpecs) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *rocketSpecs) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *rocketSpecs) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch1(l, v)
+}
+func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch2(in *jlexer.Lexer, out *rangefinderSpecs) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "rays":
+			out.Rays = int(in.Int())
+		case "angle":
+			out.Angle = types.Angle(in.Float64())
+		case "range":
+			out.Range = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch2(out *jwriter.Writer, in rangefinderSpecs) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"rays\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Rays))
+	}
+	{
+		const prefix string = ",\"angle\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Angle))
+	}
+	{
+		const prefix string = ",\"range\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Range))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v rangefinderSpecs) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v rangefinderSpecs) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *rangefinderSpecs) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *rangefinderSpecs) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch2(l, v)
+}
+func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch3(in *jlexer.Lexer, out *laserSpecs) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "shootcost":
+			out.ShootCost = float64(in.Float64())
+		case "shootcooldown":
+			out.ShootCooldown = int(in.Int())
+		case "damage":
+			out.Damage = float64(in.Float64())
+		case "range":
+			out.Range = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch3(out *jwriter.Writer, in laserSpecs) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"shootcost\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.ShootCost))
+	}
+	{
+		const prefix string = ",\"shootcooldown\":"
+		out.RawString(prefix)
+		out.Int(int(in.ShootCooldown))
+	}
+	{
+		const prefix string = ",\"damage\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Damage))
+	}
+	{
+		const prefix string = ",\"range\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.Range))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v laserSpecs) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v laserSpecs) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *laserSpecs) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *laserSpecs) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch3(l, v)
+}
+func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch4(in *jlexer.Lexer, out *hearingSpecs) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "radius":
+			out.Radius = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch4(out *jwriter.Writer, in hearingSpecs) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"radius\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.Radius))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v hearingSpecs) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v hearingSpecs) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *hearingSpecs) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *hearingSpecs) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch4(l, v)
+}
+func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch5(in *jlexer.Lexer, out *gunSpecs) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "shootcost":
+			out.ShootCost = float64(in.Float64())
+		case "shootcooldown":
+			out.ShootCooldown = int(in.Int())
+		case "projectilespeed":
+			out.ProjectileSpeed = float64(in.Float64())
+		case "projectiledamage":
+			out.ProjectileDamage = float64(in.Float64())
+		case "projectilerange":
+			out.ProjectileRange = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch5(out *jwriter.Writer, in gunSpecs) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"shootcost\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.ShootCost))
+	}
+	{
+		const prefix string = ",\"shootcooldown\":"
+		out.RawString(prefix)
+		out.Int(int(in.ShootCooldown))
+	}
+	{
+		const prefix string = ",\"projectilespeed\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ProjectileSpeed))
+	}
+	{
+		const prefix string = ",\"projectiledamage\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ProjectileDamage))
+	}
+	{
+		const prefix string = ",\"projectilerange\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ProjectileRange))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v gunSpecs) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v gunSpecs) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *gunSpecs) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *gunSpecs) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch5(l, v)
+}
+func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch6(in *jlexer.Lexer, out *agentSpecs) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -152,16 +596,14 @@ func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch1(in *jlexer.Lexe
 			out.MaxShootEnergy = float64(in.Float64())
 		case "shootrecoveryrate":
 			out.ShootRecoveryRate = float64(in.Float64())
+		case "defaultweapon":
+			out.DefaultWeapon = string(in.String())
 		case "gear":
 			if in.IsNull() {
 				in.Skip()
 			} else {
 				in.Delim('{')
-				if !in.IsDelim('}') {
-					out.Gear = make(map[string]agentGearSpecs)
-				} else {
-					out.Gear = nil
-				}
+				out.Gear = make(map[string]agentGearSpecs)
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
@@ -172,6 +614,34 @@ func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch1(in *jlexer.Lexe
 				}
 				in.Delim('}')
 			}
+		case "maxsaypayloadsize":
+			out.MaxSayPayloadSize = int(in.Int())
+		case "maxsaybytespertick":
+			out.MaxSayBytesPerTick = int(in.Int())
+		case "maxsayradius":
+			out.MaxSayRadius = float64(in.Float64())
+		case "sensors":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Sensors = make(map[string]interface{})
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v2 interface{}
+					if m, ok := v2.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v2.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v2 = in.Interface()
+					}
+					(out.Sensors)[key] = v2
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -182,78 +652,117 @@ func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch1(in *jlexer.Lexe
 		in.Consumed()
 	}
 }
-func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch1(out *jwriter.Writer, in agentSpecs) {
+func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch6(out *jwriter.Writer, in agentSpecs) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"maxspeed\":")
-	out.Float64(float64(in.MaxSpeed))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"maxsteeringforce\":")
-	out.Float64(float64(in.MaxSteeringForce))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"maxangularvelocity\":")
-	out.Float64(float64(in.MaxAngularVelocity))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"visionradius\":")
-	out.Float64(float64(in.VisionRadius))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"visionangle\":")
-	out.Float64(float64(in.VisionAngle))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"bodyradius\":")
-	out.Float64(float64(in.BodyRadius))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"maxshootenergy\":")
-	out.Float64(float64(in.MaxShootEnergy))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"shootrecoveryrate\":")
-	out.Float64(float64(in.ShootRecoveryRate))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"gear\":")
-	if in.Gear == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
-		out.RawString(`null`)
-	} else {
-		out.RawByte('{')
-		v2First := true
-		for v2Name, v2Value := range in.Gear {
-			if !v2First {
-				out.RawByte(',')
+	{
+		const prefix string = ",\"maxspeed\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.MaxSpeed))
+	}
+	{
+		const prefix string = ",\"maxsteeringforce\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.MaxSteeringForce))
+	}
+	{
+		const prefix string = ",\"maxangularvelocity\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.MaxAngularVelocity))
+	}
+	{
+		const prefix string = ",\"visionradius\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.VisionRadius))
+	}
+	{
+		const prefix string = ",\"visionangle\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.VisionAngle))
+	}
+	{
+		const prefix string = ",\"bodyradius\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.BodyRadius))
+	}
+	{
+		const prefix string = ",\"maxshootenergy\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.MaxShootEnergy))
+	}
+	{
+		const prefix string = ",\"shootrecoveryrate\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.ShootRecoveryRate))
+	}
+	{
+		const prefix string = ",\"defaultweapon\":"
+		out.RawString(prefix)
+		out.String(string(in.DefaultWeapon))
+	}
+	{
+		const prefix string = ",\"gear\":"
+		out.RawString(prefix)
+		if in.Gear == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v3First := true
+			for v3Name, v3Value := range in.Gear {
+				if v3First {
+					v3First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v3Name))
+				out.RawByte(':')
+				(v3Value).MarshalEasyJSON(out)
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"maxsaypayloadsize\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxSayPayloadSize))
+	}
+	{
+		const prefix string = ",\"maxsaybytespertick\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxSayBytesPerTick))
+	}
+	{
+		const prefix string = ",\"maxsayradius\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.MaxSayRadius))
+	}
+	{
+		const prefix string = ",\"sensors\":"
+		out.RawString(prefix)
+		if in.Sensors == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v4First := true
+			for v4Name, v4Value := range in.Sensors {
+				if v4First {
+					v4First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v4Name))
+				out.RawByte(':')
+				if m, ok := v4Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v4Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v4Value))
+				}
 			}
-			v2First = false
-			out.String(string(v2Name))
-			out.RawByte(':')
-			(v2Value).MarshalEasyJSON(out)
+			out.RawByte('}')
 		}
-		out.RawByte('}')
 	}
 	out.RawByte('}')
 }
@@ -261,27 +770,27 @@ func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch1(out *jwriter.Wr
 // MarshalJSON supports json.Marshaler interface
 func (v agentSpecs) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch1(&w, v)
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v agentSpecs) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch1(w, v)
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *agentSpecs) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch1(&r, v)
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *agentSpecs) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch1(l, v)
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch6(l, v)
 }
-func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch2(in *jlexer.Lexer, out *agentGearSpecs) {
+func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch7(in *jlexer.Lexer, out *agentGearSpecs) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -322,33 +831,30 @@ func easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch2(in *jlexer.Lexe
 		in.Consumed()
 	}
 }
-func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch2(out *jwriter.Writer, in agentGearSpecs) {
+func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch7(out *jwriter.Writer, in agentGearSpecs) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"genre\":")
-	out.String(string(in.Genre))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"kind\":")
-	out.String(string(in.Kind))
-	if !first {
-		out.RawByte(',')
-	}
-	first = false
-	out.RawString("\"specs\":")
-	if m, ok := in.Specs.(easyjson.Marshaler); ok {
-		m.MarshalEasyJSON(out)
-	} else if m, ok := in.Specs.(json.Marshaler); ok {
-		out.Raw(m.MarshalJSON())
-	} else {
-		out.Raw(json.Marshal(in.Specs))
+	{
+		const prefix string = ",\"genre\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Genre))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"specs\":"
+		out.RawString(prefix)
+		if m, ok := in.Specs.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(out)
+		} else if m, ok := in.Specs.(json.Marshaler); ok {
+			out.Raw(m.MarshalJSON())
+		} else {
+			out.Raw(json.Marshal(in.Specs))
+		}
 	}
 	out.RawByte('}')
 }
@@ -356,23 +862,23 @@ func easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch2(out *jwriter.Wr
 // MarshalJSON supports json.Marshaler interface
 func (v agentGearSpecs) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch2(&w, v)
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v agentGearSpecs) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch2(w, v)
+	easyjson69d6c65dEncodeGithubComBytearenaCoreGameDeathmatch7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *agentGearSpecs) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch2(&r, v)
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *agentGearSpecs) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch2(l, v)
+	easyjson69d6c65dDecodeGithubComBytearenaCoreGameDeathmatch7(l, v)
 }
@@ -2,6 +2,7 @@ package deathmatch
 
 import (
 	"github.com/bytearena/box2d"
+	"github.com/bytearena/ecs"
 
 	commontypes "github.com/bytearena/core/common/types"
 )
@@ -18,6 +19,11 @@ type collisionFilter struct { /* implements box2d.B2World.B2ContactFilterInterfa
 
 func (filter *collisionFilter) ShouldCollide(fixtureA *box2d.B2Fixture, fixtureB *box2d.B2Fixture) bool {
 
+	if isSolidOutline(fixtureA) || isSolidOutline(fixtureB) {
+		// solid obstacles collide through their convex pieces
+		return false
+	}
+
 	descriptorA, ok := fixtureA.GetBody().GetUserData().(commontypes.PhysicalBodyDescriptor)
 	if !ok {
 		return false
@@ -28,10 +34,19 @@ func (filter *collisionFilter) ShouldCollide(fixtureA *box2d.B2Fixture, fixtureB
 		return false
 	}
 
-	game := filter.game
+	if isSweptPair(descriptorA, descriptorB) {
+		// hit-tested by systemProjectileHits
+		return false
+	}
+
+	return mayCollide(filter.game, descriptorA.ID, descriptorB.ID)
+}
 
-	entityResultA := game.getEntity(descriptorA.ID, game.collidableComponent)
-	entityResultB := game.getEntity(descriptorB.ID, game.collidableComponent)
+// Collision groups and ownership; Box2D contacts and projectile hits alike
+func mayCollide(game *DeathmatchGame, entityIDA ecs.EntityID, entityIDB ecs.EntityID) bool {
+
+	entityResultA := game.getEntity(entityIDA, game.collidableComponent)
+	entityResultB := game.getEntity(entityIDB, game.collidableComponent)
 
 	if entityResultA == nil || entityResultB == nil {
 		return false
@@ -49,19 +64,30 @@ func (filter *collisionFilter) ShouldCollide(fixtureA *box2d.B2Fixture, fixtureB
 	// groups can collide; still have to check if there's an owner/owned relationship between the two (owned cannot collide with owner)
 	// filtering here because unfiltered collisions do have an impact on Box2D bodies movements
 
-	entityResultOwnedA := game.getEntity(descriptorA.ID, game.ownedComponent)
-	entityResultOwnedB := game.getEntity(descriptorB.ID, game.ownedComponent)
+	entityResultOwnedA := game.getEntity(entityIDA, game.ownedComponent)
+	entityResultOwnedB := game.getEntity(entityIDB, game.ownedComponent)
 
 	if entityResultOwnedA != nil {
 		ownedAspect := entityResultOwnedA.Components[game.ownedComponent].(*Owned)
-		if ownedAspect.GetOwner() == descriptorB.ID {
+		if ownedAspect.GetOwner() == entityIDB {
 			return false
 		}
 	}
 
 	if entityResultOwnedB != nil {
 		ownedAspect := entityResultOwnedB.Components[game.ownedComponent].(*Owned)
-		if ownedAspect.GetOwner() == descriptorA.ID {
+		if ownedAspect.GetOwner() == entityIDA {
+			return false
+		}
+	}
+
+	isProjectilePair := collidableAspectA.collisiongroup == CollisionGroup.Projectile && collidableAspectB.collisiongroup == CollisionGroup.Projectile
+
+	if isProjectilePair && entityResultOwnedA != nil && entityResultOwnedB != nil {
+		ownedAspectA := entityResultOwnedA.Components[game.ownedComponent].(*Owned)
+		ownedAspectB := entityResultOwnedB.Components[game.ownedComponent].(*Owned)
+		if ownedAspectA.GetOwner() == ownedAspectB.GetOwner() {
+			// projectiles of the same shooter (pellets of the same shot, for instance) cannot collide
 			return false
 		}
 	}
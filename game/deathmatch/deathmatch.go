@@ -2,18 +2,19 @@ package deathmatch
 
 import (
 	"encoding/json"
-	"log"
 	"strconv"
 
 	ebus "github.com/asaskevich/EventBus"
-	"github.com/go-gl/mathgl/mgl64"
 
 	"github.com/bytearena/box2d"
 	"github.com/bytearena/ecs"
 
 	"github.com/bytearena/core/common/types"
 	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/types/mapcontainer"
 	"github.com/bytearena/core/common/utils"
+	"github.com/bytearena/core/common/utils/space"
+	"github.com/bytearena/core/common/utils/vector"
 	"github.com/bytearena/core/game/deathmatch/events"
 	"github.com/bytearena/core/game/deathmatch/mailboxmessages"
 )
@@ -26,15 +27,7 @@ type DeathmatchGame struct {
 
 	bus ebus.Bus
 
-	physicalToAgentSpaceTransform   *mgl64.Mat4
-	physicalToAgentSpaceTranslation [3]float64
-	physicalToAgentSpaceRotation    [3]float64
-	physicalToAgentSpaceScale       float64
-
-	physicalToAgentSpaceInverseTransform   *mgl64.Mat4
-	physicalToAgentSpaceInverseTranslation [3]float64
-	physicalToAgentSpaceInverseRotation    [3]float64
-	physicalToAgentSpaceInverseScale       float64
+	spaces *space.Spaces // map, physical, agent and viz coordinate spaces
 
 	physicalBodyComponent *ecs.Component
 	healthComponent       *ecs.Component
@@ -51,46 +44,87 @@ type DeathmatchGame struct {
 	respawnComponent      *ecs.Component
 	mailboxComponent      *ecs.Component
 	sensorComponent       *ecs.Component
-
-	agentsView      *ecs.View
-	renderableView  *ecs.View
-	physicalView    *ecs.View
-	perceptorsView  *ecs.View
-	shootingView    *ecs.View
-	steeringView    *ecs.View
-	impactorView    *ecs.View
-	lifecycleView   *ecs.View
-	respawnView     *ecs.View
-	mailboxView     *ecs.View
-	playerView      *ecs.View
-	playerStatsView *ecs.View
+	pickupComponent       *ecs.Component
+	speedBoostComponent   *ecs.Component
+	teamComponent         *ecs.Component
+	flagComponent         *ecs.Component
+	destructibleComponent *ecs.Component
+	motionComponent       *ecs.Component
+	messagingComponent    *ecs.Component
+
+	agentsView       *ecs.View
+	renderableView   *ecs.View
+	physicalView     *ecs.View
+	perceptorsView   *ecs.View
+	shootingView     *ecs.View
+	steeringView     *ecs.View
+	impactorView     *ecs.View
+	lifecycleView    *ecs.View
+	respawnView      *ecs.View
+	mailboxView      *ecs.View
+	playerView       *ecs.View
+	playerStatsView  *ecs.View
+	pickupView       *ecs.View
+	speedBoostView   *ecs.View
+	destructibleView *ecs.View
+	motionView       *ecs.View
+	messagingView    *ecs.View
 
 	PhysicalWorld     *box2d.B2World
 	collisionListener *collisionListener
+	spatialIndex      *spatialIndex
+	perceptionPool    *utils.WorkerPool // computes the perceptions; sized to GOMAXPROCS
+
+	projectileHits []projectileHit // swept hits of the tick, in time order; turned into collisions by systemCollisions
+
+	impacts    []impact    // hitscan and splash impacts of the tick, applied by systemHealth
+	explosions []explosion // explosions of the tick, turned into impacts by systemHealth
+	beams      []beam      // hitscan shots of the tick, sent to the viz
+	says       []said      // messages delivered during the tick, sent to the viz
+	sounds     []sound     // shots and impacts of the tick, heard by the agents equipped with hearing
+
+	sensors         map[string]bool  // sensors the agents are equipped with, besides vision
+	perceptionNoise *perceptionNoise // nil if the perception is exact
+	physics         physicsProfile
+
+	mapGeometryIssues []mapcontainer.MapGeometryIssue
+
+	teams           []string // empty if agents do not play in teams
+	nbTeamsAssigned int
+
+	ctf  *ctfState  // nil unless variant is ctf
+	koth *kothState // nil unless variant is koth
+	race *raceState // nil unless variant is race or maze
+
+	rules *rulesState
 
 	vizframe []byte
 
 	variant     string
-	cbkGameOver func()
+	cbkGameOver func(reason string)
 }
 
 func NewDeathmatchGame(gameDescription commontypes.GameDescriptionInterface) *DeathmatchGame {
 	manager := ecs.NewManager()
 
-	transform := mgl64.Ident4()
-	inverseTransform := mgl64.Ident4()
-
 	game := &DeathmatchGame{
 		gameDescription: gameDescription,
 		manager:         manager,
 
-		// Variant: empty, or maze
+		// Variant: empty, maze, race, ctf or koth
 		variant: gameDescription.GetMapContainer().Meta.Variant,
 
+		sensors:         getSensorsFromOptions(gameDescription.GetMapContainer()),
+		perceptionNoise: makePerceptionNoise(gameDescription.GetMapContainer()),
+		physics:         makePhysicsProfile(gameDescription.GetMapContainer()),
+
 		bus: ebus.New(),
 
-		physicalToAgentSpaceTransform:        &transform,
-		physicalToAgentSpaceInverseTransform: &inverseTransform,
+		spaces: space.NewSpaces(
+			100.0,               // scale
+			[3]float64{0, 0, 0}, // translation
+			[3]float64{0, 0, 0}, // rotation
+		),
 
 		physicalBodyComponent: manager.NewComponent(),
 		healthComponent:       manager.NewComponent(),
@@ -107,14 +141,22 @@ func NewDeathmatchGame(gameDescription commontypes.GameDescriptionInterface) *De
 		respawnComponent:      manager.NewComponent(),
 		mailboxComponent:      manager.NewComponent(),
 		sensorComponent:       manager.NewComponent(),
+		pickupComponent:       manager.NewComponent(),
+		speedBoostComponent:   manager.NewComponent(),
+		teamComponent:         manager.NewComponent(),
+		flagComponent:         manager.NewComponent(),
+		destructibleComponent: manager.NewComponent(),
+		motionComponent:       manager.NewComponent(),
+		messagingComponent:    manager.NewComponent(),
+
+		projectileHits: make([]projectileHit, 0),
+
+		impacts:    make([]impact, 0),
+		explosions: make([]explosion, 0),
+		beams:      make([]beam, 0),
+		says:       make([]said, 0),
 	}
 
-	game.setPhysicalToAgentSpaceTransform(
-		100.0,               // scale
-		[3]float64{0, 0, 0}, // translation
-		[3]float64{0, 0, 0}, // rotation
-	)
-
 	gravity := box2d.MakeB2Vec2(0.0, 0.0) // gravity 0: the simulation is seen from the top
 	world := box2d.MakeB2World(gravity)
 	game.PhysicalWorld = &world
@@ -174,6 +216,34 @@ func NewDeathmatchGame(gameDescription commontypes.GameDescriptionInterface) *De
 		game.playerComponent,
 	)
 
+	game.pickupView = manager.CreateView(
+		game.pickupComponent,
+		game.physicalBodyComponent,
+	)
+
+	game.speedBoostView = manager.CreateView(
+		game.speedBoostComponent,
+		game.physicalBodyComponent,
+	)
+
+	game.destructibleView = manager.CreateView(
+		game.destructibleComponent,
+		game.healthComponent,
+	)
+
+	game.motionView = manager.CreateView(
+		game.motionComponent,
+		game.physicalBodyComponent,
+	)
+
+	game.messagingView = manager.CreateView(
+		game.messagingComponent,
+		game.physicalBodyComponent,
+	)
+
+	game.spatialIndex = newSpatialIndex(game)
+	game.perceptionPool = utils.NewWorkerPool(0, 0)
+
 	game.physicalBodyComponent.SetDestructor(func(entity *ecs.Entity, data interface{}) {
 		physicalAspect := data.(*PhysicalBody)
 		game.PhysicalWorld.DestroyBody(physicalAspect.GetBody())
@@ -200,49 +270,40 @@ func NewDeathmatchGame(gameDescription commontypes.GameDescriptionInterface) *De
 	})
 
 	game.BusSubscribe(events.EntityHit{}, game.onEntityHit)
+	game.BusSubscribe(events.EntityDestroyed{}, game.onEntityDestroyed)
+	game.BusSubscribe(events.EntityFragged{}, game.onEntityFraggedRules)
+	game.BusSubscribe(events.MatchEnded{}, game.onMatchEnded)
 	game.BusSubscribe(events.EntityRespawning{}, game.onEntityRespawning)
 	game.BusSubscribe(events.EntityRespawned{}, game.onEntityRespawned)
+	game.BusSubscribe(events.EntityRestarted{}, game.onEntityRestarted)
+	game.BusSubscribe(events.EntityCollectedPickup{}, game.onEntityCollectedPickup)
 
-	if game.variant == "maze" {
-		game.BusSubscribe(events.EntityExitedMaze{}, game.onEntityExitedMaze)
+	if game.sensors[sensorKind.Hearing] {
+		game.BusSubscribe(events.EntityHit{}, game.onEntityHitSound)
+		game.BusSubscribe(events.EntityFragged{}, game.onEntityFraggedSound)
 	}
 
-	return game
-}
-
-func (deathmatch *DeathmatchGame) Initialize(cbkGameOver func()) {
-	deathmatch.cbkGameOver = cbkGameOver
-}
-
-func (deathmatch *DeathmatchGame) setPhysicalToAgentSpaceTransform(scale float64, translation, rotation [3]float64) *DeathmatchGame {
-
-	deathmatch.physicalToAgentSpaceScale = scale
-	deathmatch.physicalToAgentSpaceTranslation = translation
-	deathmatch.physicalToAgentSpaceRotation = rotation
-
-	rotxM := mgl64.HomogRotate3DX(mgl64.DegToRad(deathmatch.physicalToAgentSpaceRotation[0]))
-	rotyM := mgl64.HomogRotate3DY(mgl64.DegToRad(deathmatch.physicalToAgentSpaceRotation[1]))
-	rotzM := mgl64.HomogRotate3DZ(mgl64.DegToRad(deathmatch.physicalToAgentSpaceRotation[2]))
-	transM := mgl64.Translate3D(deathmatch.physicalToAgentSpaceTranslation[0], deathmatch.physicalToAgentSpaceTranslation[1], deathmatch.physicalToAgentSpaceTranslation[2])
-	scaleM := mgl64.Scale3D(deathmatch.physicalToAgentSpaceScale, deathmatch.physicalToAgentSpaceScale, deathmatch.physicalToAgentSpaceScale)
-
-	transform := mgl64.Ident4().
-		Mul4(transM).
-		Mul4(rotzM).
-		Mul4(rotyM).
-		Mul4(rotxM).
-		Mul4(scaleM)
+	if game.variant == "maze" || game.variant == "race" {
+		game.BusSubscribe(events.EntityReachedCheckpoint{}, game.onEntityReachedCheckpoint)
+		game.BusSubscribe(events.EntityFinishedRace{}, game.onEntityFinishedRace)
+	}
 
-	deathmatch.physicalToAgentSpaceTransform = &transform
+	if game.variant == "ctf" {
+		game.BusSubscribe(events.EntityFragged{}, game.onEntityFraggedCTF)
+		game.BusSubscribe(events.FlagTaken{}, game.onFlagTaken)
+		game.BusSubscribe(events.FlagReturned{}, game.onFlagReturned)
+		game.BusSubscribe(events.FlagCaptured{}, game.onFlagCaptured)
+	}
 
-	deathmatch.physicalToAgentSpaceInverseScale = 1.0 / scale
-	deathmatch.physicalToAgentSpaceInverseTranslation = [3]float64{translation[0] * -1, translation[1] * -1, translation[2] * -1}
-	deathmatch.physicalToAgentSpaceInverseRotation = [3]float64{rotation[0] * -1, rotation[1] * -1, rotation[2] * -1}
+	if game.variant == "koth" {
+		game.BusSubscribe(events.ZoneControlChanged{}, game.onZoneControlChanged)
+	}
 
-	inv := deathmatch.physicalToAgentSpaceTransform.Inv()
-	deathmatch.physicalToAgentSpaceInverseTransform = &inv
+	return game
+}
 
-	return deathmatch
+func (deathmatch *DeathmatchGame) Initialize(cbkGameOver func(reason string)) {
+	deathmatch.cbkGameOver = cbkGameOver
 }
 
 func (deathmatch DeathmatchGame) getEntity(id ecs.EntityID, tagelements ...interface{}) *ecs.QueryResult {
@@ -253,6 +314,15 @@ func (deathmatch DeathmatchGame) getEntity(id ecs.EntityID, tagelements ...inter
 
 func (deathmatch *DeathmatchGame) ImplementsGameInterface() {}
 
+// Releases the workers of the game; the game cannot step anymore
+func (deathmatch *DeathmatchGame) TearDown() {
+	deathmatch.perceptionPool.Stop()
+}
+
+func (deathmatch *DeathmatchGame) GetMapGeometryIssues() []mapcontainer.MapGeometryIssue {
+	return deathmatch.mapGeometryIssues
+}
+
 func (deathmatch *DeathmatchGame) Step(ticknum int, dt float64, mutations []types.AgentMutationBatch) {
 
 	//watch := utils.MakeStopwatch("deathmatch::Step()")
@@ -272,6 +342,11 @@ func (deathmatch *DeathmatchGame) Step(ticknum int, dt float64, mutations []type
 	systemDeath(deathmatch, respawnersTag.Inverse())
 	//watch.Stop("systemDeath")
 
+	///////////////////////////////////////////////////////////////////////////
+	// On distribue les messages dits par les agents au tour précédent
+	///////////////////////////////////////////////////////////////////////////
+	systemMessaging(deathmatch)
+
 	///////////////////////////////////////////////////////////////////////////
 	// On traite les mutations
 	///////////////////////////////////////////////////////////////////////////
@@ -293,6 +368,14 @@ func (deathmatch *DeathmatchGame) Step(ticknum int, dt float64, mutations []type
 	systemSteering(deathmatch)
 	//watch.Stop("systemSteering")
 
+	// Obstacles mobiles
+	systemMotion(deathmatch)
+
+	///////////////////////////////////////////////////////////////////////////
+	// On calcule les impacts des projectiles avant qu'ils ne bougent
+	///////////////////////////////////////////////////////////////////////////
+	systemProjectileHits(deathmatch)
+
 	///////////////////////////////////////////////////////////////////////////
 	// On met l'état des objets physiques à jour
 	///////////////////////////////////////////////////////////////////////////
@@ -335,6 +418,26 @@ func (deathmatch *DeathmatchGame) Step(ticknum int, dt float64, mutations []type
 	systemRespawn(deathmatch)
 	//watch.Stop("systemRespawn")
 
+	///////////////////////////////////////////////////////////////////////////
+	// On fait réapparaître les bonus et expirer leurs effets
+	///////////////////////////////////////////////////////////////////////////
+	systemPickups(deathmatch)
+
+	///////////////////////////////////////////////////////////////////////////
+	// On déplace les drapeaux avec leur porteur
+	///////////////////////////////////////////////////////////////////////////
+	systemCTF(deathmatch)
+
+	///////////////////////////////////////////////////////////////////////////
+	// On détermine qui contrôle la zone
+	///////////////////////////////////////////////////////////////////////////
+	systemKOTH(deathmatch)
+
+	///////////////////////////////////////////////////////////////////////////
+	// On suit la course (arrivées, temps limite)
+	///////////////////////////////////////////////////////////////////////////
+	systemRace(deathmatch)
+
 	///////////////////////////////////////////////////////////////////////////
 	// On calcule les stats des agents
 	///////////////////////////////////////////////////////////////////////////
@@ -345,6 +448,11 @@ func (deathmatch *DeathmatchGame) Step(ticknum int, dt float64, mutations []type
 	///////////////////////////////////////////////////////////////////////////
 	systemScore(deathmatch)
 
+	///////////////////////////////////////////////////////////////////////////
+	// On applique les règles du match (limites, fin de partie)
+	///////////////////////////////////////////////////////////////////////////
+	systemRules(deathmatch)
+
 	///////////////////////////////////////////////////////////////////////////
 	// Fetching and emptying mailboxes for entities mailed in this tick
 	///////////////////////////////////////////////////////////////////////////
@@ -369,6 +477,8 @@ func (deathmatch *DeathmatchGame) Step(ticknum int, dt float64, mutations []type
 	//fmt.Println(watch.String())
 
 	deathmatch.ComputeVizFrame(mailboxes)
+
+	deathmatch.notifyGameOver()
 }
 
 func (deathmatch *DeathmatchGame) GetAgentPerception(entityid ecs.EntityID) []byte {
@@ -393,6 +503,7 @@ func (deathmatch *DeathmatchGame) GetAgentWelcome(entityid ecs.EntityID) []byte
 		deathmatch.steeringComponent,
 		deathmatch.shootingComponent,
 		deathmatch.perceptionComponent,
+		deathmatch.messagingComponent,
 	)
 
 	if entityresult == nil {
@@ -403,6 +514,7 @@ func (deathmatch *DeathmatchGame) GetAgentWelcome(entityid ecs.EntityID) []byte
 	steeringAspect := entityresult.Components[deathmatch.steeringComponent].(*Steering)
 	shootingAspect := entityresult.Components[deathmatch.shootingComponent].(*Shooting)
 	perceptionAspect := entityresult.Components[deathmatch.perceptionComponent].(*Perception)
+	messagingAspect := entityresult.Components[deathmatch.messagingComponent].(*Messaging)
 
 	p := agentSpecs{
 		// Movement
@@ -419,21 +531,24 @@ func (deathmatch *DeathmatchGame) GetAgentWelcome(entityid ecs.EntityID) []byte
 		MaxShootEnergy:    shootingAspect.MaxShootEnergy,
 		ShootRecoveryRate: shootingAspect.ShootRecoveryRate,
 
-		// DefaultWeapon: "gun",
-
-		Gear: map[string]agentGearSpecs{
-			"gun": agentGearSpecs{
-				Genre: "weapon",
-				Kind:  "gun",
-				Specs: gunSpecs{
-					ShootCost:        shootingAspect.ShootCost,
-					ShootCooldown:    shootingAspect.ShootCooldown,
-					ProjectileSpeed:  shootingAspect.ProjectileSpeed,
-					ProjectileDamage: shootingAspect.ProjectileDamage,
-					ProjectileRange:  shootingAspect.ProjectileRange,
-				},
-			},
-		},
+		DefaultWeapon: shootingAspect.DefaultWeapon,
+
+		Gear: make(map[string]agentGearSpecs),
+
+		// Say
+		MaxSayPayloadSize:  messagingAspect.MaxPayloadSize,
+		MaxSayBytesPerTick: messagingAspect.MaxBytesPerTick,
+		MaxSayRadius:       messagingAspect.MaxRadius,
+
+		Sensors: makeSensorsSpecs(perceptionAspect),
+	}
+
+	for name, weapon := range shootingAspect.Weapons {
+		p.Gear[name] = agentGearSpecs{
+			Genre: "weapon",
+			Kind:  weapon.Kind,
+			Specs: makeWeaponSpecs(weapon),
+		}
 	}
 
 	res, _ := p.MarshalJSON()
@@ -478,6 +593,7 @@ func (deathmatch *DeathmatchGame) ComputeVizFrame(mailboxes map[ecs.EntityID]([]
 	msg := commontypes.VizMessage{
 		GameID:        deathmatch.gameDescription.GetId(),
 		Objects:       []commontypes.VizMessageObject{},
+		Obstacles:     []commontypes.VizMessageObstacle{},
 		DebugPoints:   make([][2]float64, 0),
 		DebugSegments: make([][2][2]float64, 0),
 		Events:        []commontypes.VizMessageEvent{},
@@ -488,14 +604,19 @@ func (deathmatch *DeathmatchGame) ComputeVizFrame(mailboxes map[ecs.EntityID]([]
 		renderAspect := entityresult.Components[deathmatch.renderComponent].(*Render)
 		physicalBodyAspect := entityresult.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
 
+		if pickupQr := deathmatch.getEntity(entityresult.Entity.ID, deathmatch.pickupComponent); pickupQr != nil {
+			if !pickupQr.Components[deathmatch.pickupComponent].(*Pickup).IsAvailable() {
+				// collected pickups are hidden until they respawn
+				continue
+			}
+		}
+
 		obj := commontypes.VizMessageObject{
 			Id:   entityresult.Entity.GetID().String(),
 			Type: renderAspect.GetType(),
 
-			// Here, viz coord space and physical world coord space match
-			// No transform is therefore needed
-			Position:    physicalBodyAspect.GetPhysicalReferentialPosition(),
-			Velocity:    physicalBodyAspect.GetPhysicalReferentialVelocity(),
+			Position:    deathmatch.spaces.PhysicalToViz(physicalBodyAspect.GetPhysicalReferentialPosition()).Vector2(),
+			Velocity:    deathmatch.spaces.PhysicalToViz(physicalBodyAspect.GetPhysicalReferentialVelocity()).Vector2(),
 			Radius:      physicalBodyAspect.GetPhysicalReferentialRadius(),
 			Orientation: physicalBodyAspect.GetPhysicalReferentialOrientation(),
 
@@ -529,8 +650,9 @@ func (deathmatch *DeathmatchGame) ComputeVizFrame(mailboxes map[ecs.EntityID]([]
 
 		// scaledDebugPoints := make([][2]float64, len(renderAspect.DebugPoints))
 		// for i := 0; i < len(renderAspect.DebugPoints); i++ {
-		// 	scaledDebugPoints[i] = vector.Vector2(renderAspect.DebugPoints[i]).
-		// 		Transform(deathmatch.physicalToAgentSpaceInverseTransform).
+		// 	scaledDebugPoints[i] = deathmatch.spaces.
+		// 		AgentToViz(space.MakeAgentVector2FromVector2(vector.Vector2(renderAspect.DebugPoints[i]))).
+		// 		Vector2().
 		// 		ToFloatArray()
 		// }
 		// msg.DebugPoints = append(msg.DebugPoints, scaledDebugPoints...)
@@ -538,13 +660,92 @@ func (deathmatch *DeathmatchGame) ComputeVizFrame(mailboxes map[ecs.EntityID]([]
 		// scaledDebugSegments := make([][2][2]float64, len(renderAspect.DebugSegments))
 		// for i := 0; i < len(renderAspect.DebugSegments); i++ {
 		// 	scaledDebugSegments[i] = [2][2]float64{
-		// 		vector.Vector2(renderAspect.DebugSegments[i][0]).Transform(deathmatch.physicalToAgentSpaceInverseTransform).ToFloatArray(),
-		// 		vector.Vector2(renderAspect.DebugSegments[i][1]).Transform(deathmatch.physicalToAgentSpaceInverseTransform).ToFloatArray(),
+		// 		deathmatch.spaces.AgentToViz(space.MakeAgentVector2FromVector2(vector.Vector2(renderAspect.DebugSegments[i][0]))).Vector2().ToFloatArray(),
+		// 		deathmatch.spaces.AgentToViz(space.MakeAgentVector2FromVector2(vector.Vector2(renderAspect.DebugSegments[i][1]))).Vector2().ToFloatArray(),
 		// 	}
 		// }
 		// msg.DebugSegments = append(msg.DebugSegments, scaledDebugSegments...)
 	}
 
+	// Damage state of destructible obstacles
+	obstacleIndexes := make(map[ecs.EntityID]int)
+	for _, entityresult := range deathmatch.destructibleView.Get() {
+		destructibleAspect := entityresult.Components[deathmatch.destructibleComponent].(*Destructible)
+		healthAspect := entityresult.Components[deathmatch.healthComponent].(*Health)
+
+		obstacleIndexes[entityresult.Entity.GetID()] = len(msg.Obstacles)
+		msg.Obstacles = append(msg.Obstacles, commontypes.VizMessageObstacle{
+			Id:        destructibleAspect.GetMapObjectID(),
+			Life:      healthAspect.GetLife(),
+			MaxLife:   healthAspect.GetMaxLife(),
+			Destroyed: healthAspect.GetLife() <= 0,
+		})
+	}
+
+	// Current outline of moving obstacles
+	for _, entityresult := range deathmatch.motionView.Get() {
+		motionAspect := entityresult.Components[deathmatch.motionComponent].(*Motion)
+		physicalBodyAspect := entityresult.Components[deathmatch.physicalBodyComponent].(*PhysicalBody)
+
+		index, ok := obstacleIndexes[entityresult.Entity.GetID()]
+		if !ok {
+			index = len(msg.Obstacles)
+			msg.Obstacles = append(msg.Obstacles, commontypes.VizMessageObstacle{
+				Id: motionAspect.GetMapObjectID(),
+			})
+		}
+
+		body := physicalBodyAspect.GetBody()
+		points := make([]vector.Vector2, 0)
+		for fixture := body.GetFixtureList(); fixture != nil; fixture = fixture.GetNext() {
+			if edge, ok := fixture.GetShape().(*box2d.B2EdgeShape); ok {
+				points = append(points, deathmatch.spaces.PhysicalToViz(space.PhysicalFromB2Vec2(body.GetWorldPoint(edge.M_vertex2))).Vector2())
+			}
+		}
+
+		msg.Obstacles[index].Points = points
+	}
+
+	// Collecting hitscan shots
+	for _, b := range deathmatch.beams {
+		msg.Events = append(msg.Events, commontypes.VizMessageEvent{
+			Subject: "beam",
+			Payload: map[string][2]float64{
+				"from": deathmatch.spaces.PhysicalToViz(b.from).Vector2().ToFloatArray(),
+				"to":   deathmatch.spaces.PhysicalToViz(b.to).Vector2().ToFloatArray(),
+			},
+		})
+	}
+
+	// End of the match
+	if deathmatch.rules.ended && deathmatch.rules.pendingEnd.Tick == deathmatch.ticknum {
+		msg.Events = append(msg.Events, commontypes.VizMessageEvent{
+			Subject: "matchended",
+			Payload: map[string]string{
+				"reason": deathmatch.rules.pendingEnd.Reason,
+				"winner": deathmatch.rules.pendingEnd.Winner,
+			},
+		})
+	}
+
+	// Collecting messages between agents
+	for _, s := range deathmatch.says {
+		to := make([]string, len(s.recipients))
+		for i, recipient := range s.recipients {
+			to[i] = recipient.String()
+		}
+
+		msg.Events = append(msg.Events, commontypes.VizMessageEvent{
+			Subject: "say",
+			Payload: map[string]interface{}{
+				"who":     s.from.String(),
+				"channel": s.channel,
+				"payload": s.payload,
+				"to":      to,
+			},
+		})
+	}
+
 	// Collecting events
 	for entityid, mailbox := range mailboxes {
 
@@ -570,6 +771,18 @@ func (deathmatch *DeathmatchGame) ComputeVizFrame(mailboxes map[ecs.EntityID]([]
 				payload = map[string]string{
 					"who": strconv.Itoa(int(entityid)),
 				}
+			case mailboxmessages.RaceFinished:
+				subject = v.Subject()
+				payload = map[string]string{
+					"who":  strconv.Itoa(int(entityid)),
+					"rank": strconv.Itoa(v.Rank),
+				}
+			case mailboxmessages.YouHaveBeenRestarted:
+				subject = v.Subject()
+				payload = map[string]string{
+					"who":      strconv.Itoa(int(entityid)),
+					"restarts": strconv.Itoa(v.Restarts),
+				}
 			}
 
 			if subject != "" {
@@ -588,41 +801,55 @@ func initPhysicalWorld(deathmatch *DeathmatchGame) {
 
 	arenaMap := deathmatch.gameDescription.GetMapContainer()
 
+	// Repairs what can be; the rest is skipped, and reported to the server
+	deathmatch.mapGeometryIssues = arenaMap.ValidateGeometry()
+	for _, issue := range deathmatch.mapGeometryIssues {
+		utils.Debug("deathmatch-map", issue.String())
+	}
+
 	// Static obstacles formed by the grounds
 	for _, ground := range arenaMap.Data.Grounds {
+		if !ground.Polygon.IsUsable() {
+			continue
+		}
+
 		deathmatch.NewEntityGround(ground.Polygon, ground.Name)
 	}
 
 	// Explicit obstacles
 	for _, obstacle := range arenaMap.Data.Obstacles {
-		polygon := obstacle.Polygon
-		deathmatch.NewEntityObstacle(polygon, obstacle.Name)
-	}
-
-	if deathmatch.variant == "maze" {
-		// Sensors
-
-		for _, otherObject := range arenaMap.Data.OtherPolygonObjects {
-
-			if utils.IsStringInArray(otherObject.Tags, "maze:exit") {
-				polygon := otherObject.Polygon
-				deathmatch.NewEntitySensor(
-					polygon,
-					otherObject.Name,
-					func(entityid ecs.EntityID, sensorid ecs.EntityID) {
-						deathmatch.BusPublish(events.EntityExitedMaze{
-							Entity: entityid,
-							Exit:   sensorid,
-						})
-					},
-					utils.BuildTag(
-						CollisionGroup.Agent,
-						CollisionGroup.Projectile,
-					),
-				)
-			}
+		if !obstacle.Polygon.IsUsable() {
+			continue
+		}
+
+		if life, ok := getDestructibleLifeFromTags(obstacle.Tags); ok {
+			deathmatch.NewEntityDestructibleObstacle(obstacle, life)
+			continue
+		}
+
+		deathmatch.NewEntityMapObstacle(obstacle)
+	}
+
+	// Pickups
+	for _, otherObject := range arenaMap.Data.OtherPointObjects {
+		if kind := getPickupKindFromTags(otherObject.Tags); kind != "" {
+			deathmatch.NewEntityPickup(otherObject.Point, kind)
 		}
 	}
+
+	if deathmatch.variant == "ctf" {
+		initCTF(deathmatch, arenaMap)
+	}
+
+	if deathmatch.variant == "koth" {
+		initKOTH(deathmatch, arenaMap)
+	}
+
+	if deathmatch.variant == "maze" || deathmatch.variant == "race" {
+		initRace(deathmatch, arenaMap)
+	}
+
+	initRules(deathmatch, arenaMap)
 }
 
 func (deathmatch *DeathmatchGame) BusSubscribe(e events.EventInterface, cbk interface{}) {
@@ -633,6 +860,13 @@ func (deathmatch *DeathmatchGame) BusPublish(e events.EventInterface) {
 	deathmatch.bus.Publish(e.Topic(), e)
 }
 
+func (game *DeathmatchGame) broadcastMessage(msg mailboxmessages.MailboxMessageInterface) {
+	for _, result := range game.playerView.Get() {
+		mailboxAspect := result.Components[game.mailboxComponent].(*Mailbox)
+		mailboxAspect.PushMessage(msg)
+	}
+}
+
 func (game *DeathmatchGame) onEntityFraggedUpdateMailbox(e events.EntityFragged, fraggerEntityID ecs.EntityID) {
 
 	///////////////////////////////////////////////////////////////////////
@@ -705,32 +939,63 @@ func (game *DeathmatchGame) onEntityHit(e events.EntityHit) {
 	// Notifying hit entity
 	///////////////////////////////////////////////////////////////////////
 
+	// Destructible obstacles have no mailbox
 	query := game.getEntity(e.Entity, game.mailboxComponent)
+	if query != nil {
+		mailboxAspect := query.Components[game.mailboxComponent].(*Mailbox)
+		mailboxAspect.PushMessage(mailboxmessages.YouHaveBeenHit{
+			Kind:       "projectile",
+			ComingFrom: e.ComingFrom,
+			Damage:     e.Damage,
+		})
+	}
+
+	///////////////////////////////////////////////////////////////////////
+	// Notifying hitter entity
+	///////////////////////////////////////////////////////////////////////
+
+	query = game.getEntity(hitterEntityID, game.mailboxComponent)
 	if query == nil {
 		// should never happen
 		return
 	}
 
 	mailboxAspect := query.Components[game.mailboxComponent].(*Mailbox)
-	mailboxAspect.PushMessage(mailboxmessages.YouHaveBeenHit{
-		Kind:       "projectile",
-		ComingFrom: e.ComingFrom,
-		Damage:     e.Damage,
+	mailboxAspect.PushMessage(mailboxmessages.YouHaveHit{
+		Who: e.Entity.String(),
 	})
+}
 
-	///////////////////////////////////////////////////////////////////////
-	// Notifying hitter entity
-	///////////////////////////////////////////////////////////////////////
+func (game *DeathmatchGame) onEntityDestroyed(e events.EntityDestroyed) {
 
-	query = game.getEntity(hitterEntityID, game.mailboxComponent)
+	destroyerEntityID := e.DestroyedBy
+	ownedQuery := game.getEntity(e.DestroyedBy, game.ownedComponent)
+	if ownedQuery != nil {
+		ownedAspect := ownedQuery.Components[game.ownedComponent].(*Owned)
+		destroyerEntityID = ownedAspect.GetOwner()
+	}
+
+	query := game.getEntity(destroyerEntityID, game.mailboxComponent)
+	if query == nil {
+		return
+	}
+
+	mailboxAspect := query.Components[game.mailboxComponent].(*Mailbox)
+	mailboxAspect.PushMessage(mailboxmessages.YouHaveDestroyed{
+		Who: e.Entity.String(),
+	})
+}
+
+func (game *DeathmatchGame) onEntityCollectedPickup(e events.EntityCollectedPickup) {
+	query := game.getEntity(e.Entity, game.mailboxComponent)
 	if query == nil {
 		// should never happen
 		return
 	}
 
-	mailboxAspect = query.Components[game.mailboxComponent].(*Mailbox)
-	mailboxAspect.PushMessage(mailboxmessages.YouHaveHit{
-		Who: string(e.Entity),
+	mailboxAspect := query.Components[game.mailboxComponent].(*Mailbox)
+	mailboxAspect.PushMessage(mailboxmessages.YouHaveCollectedPickup{
+		Kind: e.Kind,
 	})
 }
 
@@ -758,18 +1023,14 @@ func (game *DeathmatchGame) onEntityRespawned(e events.EntityRespawned) {
 	mailboxAspect.PushMessage(mailboxmessages.YouHaveRespawned{})
 }
 
-func (game *DeathmatchGame) onEntityExitedMaze(e events.EntityExitedMaze) {
+func (game *DeathmatchGame) onEntityRestarted(e events.EntityRestarted) {
 	query := game.getEntity(e.Entity, game.mailboxComponent)
 	if query == nil {
-		// should never happen
 		return
 	}
 
 	mailboxAspect := query.Components[game.mailboxComponent].(*Mailbox)
-	mailboxAspect.PushMessage(mailboxmessages.YouHaveExitedTheMaze{
-		Entity: e.Entity,
+	mailboxAspect.PushMessage(mailboxmessages.YouHaveBeenRestarted{
+		Restarts: e.Restarts,
 	})
-
-	log.Println("EXITED THE MAZE")
-	game.cbkGameOver()
 }
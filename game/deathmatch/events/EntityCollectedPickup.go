@@ -0,0 +1,11 @@
+package events
+
+import "github.com/bytearena/ecs"
+
+type EntityCollectedPickup struct {
+	Entity ecs.EntityID
+	Pickup ecs.EntityID
+	Kind   string
+}
+
+func (ev EntityCollectedPickup) Topic() string { return "gameplay:entity:collectedpickup" }
@@ -0,0 +1,10 @@
+package events
+
+import "github.com/bytearena/ecs"
+
+type EntityDestroyed struct {
+	Entity      ecs.EntityID
+	DestroyedBy ecs.EntityID
+}
+
+func (ev EntityDestroyed) Topic() string { return "gameplay:entity:destroyed" }
@@ -0,0 +1,11 @@
+package events
+
+import "github.com/bytearena/ecs"
+
+type EntityFinishedRace struct {
+	Entity ecs.EntityID
+	Rank   int
+	Time   int // in ticks
+}
+
+func (ev EntityFinishedRace) Topic() string { return "gameplay:entity:finishedrace" }
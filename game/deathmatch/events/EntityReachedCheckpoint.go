@@ -0,0 +1,13 @@
+package events
+
+import "github.com/bytearena/ecs"
+
+type EntityReachedCheckpoint struct {
+	Entity     ecs.EntityID
+	Checkpoint int // index in the sequence
+	Lap        int // completed laps
+	Split      int // ticks since the previous checkpoint
+	LapTime    int // ticks of the lap completed with this checkpoint; 0 if none
+}
+
+func (ev EntityReachedCheckpoint) Topic() string { return "gameplay:entity:reachedcheckpoint" }
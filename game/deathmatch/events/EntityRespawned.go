@@ -4,7 +4,7 @@ import "github.com/bytearena/ecs"
 
 type EntityRespawned struct {
 	Entity        ecs.EntityID
-	StartingPoint [2]float64
+	StartingPoint [2]float64 // map space
 }
 
 func (ev EntityRespawned) Topic() string { return "gameplay:entity:respawned" }
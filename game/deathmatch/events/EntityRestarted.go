@@ -0,0 +1,10 @@
+package events
+
+import "github.com/bytearena/ecs"
+
+type EntityRestarted struct {
+	Entity   ecs.EntityID
+	Restarts int
+}
+
+func (ev EntityRestarted) Topic() string { return "gameplay:entity:restarted" }
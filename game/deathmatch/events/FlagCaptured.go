@@ -0,0 +1,11 @@
+package events
+
+import "github.com/bytearena/ecs"
+
+type FlagCaptured struct {
+	Flag ecs.EntityID
+	Team string // team of the captured flag
+	By   ecs.EntityID
+}
+
+func (ev FlagCaptured) Topic() string { return "gameplay:flag:captured" }
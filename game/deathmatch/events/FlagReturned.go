@@ -0,0 +1,10 @@
+package events
+
+import "github.com/bytearena/ecs"
+
+type FlagReturned struct {
+	Flag ecs.EntityID
+	Team string
+}
+
+func (ev FlagReturned) Topic() string { return "gameplay:flag:returned" }
@@ -0,0 +1,11 @@
+package events
+
+import "github.com/bytearena/ecs"
+
+type FlagTaken struct {
+	Flag ecs.EntityID
+	Team string
+	By   ecs.EntityID
+}
+
+func (ev FlagTaken) Topic() string { return "gameplay:flag:taken" }
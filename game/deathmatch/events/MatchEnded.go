@@ -0,0 +1,9 @@
+package events
+
+type MatchEnded struct {
+	Reason string // fraglimit, scorelimit, timelimit, suddendeath, lastagentstanding or objective
+	Winner string // team or agent; empty if none
+	Tick   int
+}
+
+func (ev MatchEnded) Topic() string { return "gameplay:match:ended" }
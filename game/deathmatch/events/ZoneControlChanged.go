@@ -0,0 +1,8 @@
+package events
+
+type ZoneControlChanged struct {
+	Holder    string // team or agent holding the zone; empty if none
+	Contested bool
+}
+
+func (ev ZoneControlChanged) Topic() string { return "gameplay:zone:controlchanged" }
@@ -0,0 +1,14 @@
+package mailboxmessages
+
+type CheckpointReached struct {
+	Checkpoint  int `json:"checkpoint"`  // index in the sequence
+	Checkpoints int `json:"checkpoints"` // number of checkpoints per lap
+	Lap         int `json:"lap"`         // completed laps
+	Laps        int `json:"laps"`
+	Split       int `json:"split"`   // ticks since the previous checkpoint
+	LapTime     int `json:"laptime"` // ticks of the lap completed with this checkpoint; 0 if none
+}
+
+func (msg CheckpointReached) Subject() string {
+	return "checkpoint"
+}
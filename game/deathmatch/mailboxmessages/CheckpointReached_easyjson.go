@@ -0,0 +1,120 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package mailboxmessages
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson661181f2DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(in *jlexer.Lexer, out *CheckpointReached) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "checkpoint":
+			out.Checkpoint = int(in.Int())
+		case "checkpoints":
+			out.Checkpoints = int(in.Int())
+		case "lap":
+			out.Lap = int(in.Int())
+		case "laps":
+			out.Laps = int(in.Int())
+		case "split":
+			out.Split = int(in.Int())
+		case "laptime":
+			out.LapTime = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson661181f2EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(out *jwriter.Writer, in CheckpointReached) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"checkpoint\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Checkpoint))
+	}
+	{
+		const prefix string = ",\"checkpoints\":"
+		out.RawString(prefix)
+		out.Int(int(in.Checkpoints))
+	}
+	{
+		const prefix string = ",\"lap\":"
+		out.RawString(prefix)
+		out.Int(int(in.Lap))
+	}
+	{
+		const prefix string = ",\"laps\":"
+		out.RawString(prefix)
+		out.Int(int(in.Laps))
+	}
+	{
+		const prefix string = ",\"split\":"
+		out.RawString(prefix)
+		out.Int(int(in.Split))
+	}
+	{
+		const prefix string = ",\"laptime\":"
+		out.RawString(prefix)
+		out.Int(int(in.LapTime))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CheckpointReached) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson661181f2EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CheckpointReached) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson661181f2EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CheckpointReached) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson661181f2DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CheckpointReached) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson661181f2DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(l, v)
+}
@@ -0,0 +1,11 @@
+package mailboxmessages
+
+type DidNotFinish struct {
+	Position   int `json:"position"`   // in the final ranking
+	Lap        int `json:"lap"`        // completed laps
+	Checkpoint int `json:"checkpoint"` // next checkpoint to reach
+}
+
+func (msg DidNotFinish) Subject() string {
+	return "dnf"
+}
@@ -0,0 +1,99 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package mailboxmessages
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson3df11ff3DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(in *jlexer.Lexer, out *DidNotFinish) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "position":
+			out.Position = int(in.Int())
+		case "lap":
+			out.Lap = int(in.Int())
+		case "checkpoint":
+			out.Checkpoint = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3df11ff3EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(out *jwriter.Writer, in DidNotFinish) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"position\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Position))
+	}
+	{
+		const prefix string = ",\"lap\":"
+		out.RawString(prefix)
+		out.Int(int(in.Lap))
+	}
+	{
+		const prefix string = ",\"checkpoint\":"
+		out.RawString(prefix)
+		out.Int(int(in.Checkpoint))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DidNotFinish) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3df11ff3EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DidNotFinish) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3df11ff3EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DidNotFinish) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3df11ff3DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DidNotFinish) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3df11ff3DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(l, v)
+}
@@ -0,0 +1,10 @@
+package mailboxmessages
+
+type FlagCaptured struct {
+	Team string `json:"team"`
+	By   string `json:"by"`
+}
+
+func (msg FlagCaptured) Subject() string {
+	return "flagcaptured"
+}
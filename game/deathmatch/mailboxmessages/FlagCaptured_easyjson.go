@@ -0,0 +1,92 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package mailboxmessages
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson4967fd84DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(in *jlexer.Lexer, out *FlagCaptured) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "team":
+			out.Team = string(in.String())
+		case "by":
+			out.By = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4967fd84EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(out *jwriter.Writer, in FlagCaptured) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"team\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Team))
+	}
+	{
+		const prefix string = ",\"by\":"
+		out.RawString(prefix)
+		out.String(string(in.By))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FlagCaptured) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4967fd84EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FlagCaptured) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4967fd84EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FlagCaptured) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4967fd84DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FlagCaptured) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4967fd84DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(l, v)
+}
@@ -0,0 +1,9 @@
+package mailboxmessages
+
+type FlagReturned struct {
+	Team string `json:"team"`
+}
+
+func (msg FlagReturned) Subject() string {
+	return "flagreturned"
+}
@@ -0,0 +1,85 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package mailboxmessages
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson81aba2dbDecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(in *jlexer.Lexer, out *FlagReturned) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "team":
+			out.Team = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson81aba2dbEncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(out *jwriter.Writer, in FlagReturned) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"team\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Team))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FlagReturned) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson81aba2dbEncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FlagReturned) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson81aba2dbEncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FlagReturned) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson81aba2dbDecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FlagReturned) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson81aba2dbDecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(l, v)
+}
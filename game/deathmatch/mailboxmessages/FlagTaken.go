@@ -0,0 +1,10 @@
+package mailboxmessages
+
+type FlagTaken struct {
+	Team string `json:"team"`
+	By   string `json:"by"`
+}
+
+func (msg FlagTaken) Subject() string {
+	return "flagtaken"
+}
@@ -0,0 +1,92 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package mailboxmessages
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson1958555DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(in *jlexer.Lexer, out *FlagTaken) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "team":
+			out.Team = string(in.String())
+		case "by":
+			out.By = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson1958555EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(out *jwriter.Writer, in FlagTaken) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"team\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Team))
+	}
+	{
+		const prefix string = ",\"by\":"
+		out.RawString(prefix)
+		out.String(string(in.By))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FlagTaken) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson1958555EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FlagTaken) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson1958555EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FlagTaken) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson1958555DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FlagTaken) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson1958555DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(l, v)
+}
@@ -0,0 +1,10 @@
+package mailboxmessages
+
+type MatchEnded struct {
+	Reason string `json:"reason"`
+	Winner string `json:"winner"` // team or agent; empty if none
+}
+
+func (msg MatchEnded) Subject() string {
+	return "matchended"
+}
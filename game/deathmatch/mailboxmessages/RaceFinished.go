@@ -0,0 +1,11 @@
+package mailboxmessages
+
+type RaceFinished struct {
+	Rank     int   `json:"rank"`
+	Time     int   `json:"time"`     // in ticks
+	LapTimes []int `json:"laptimes"` // in ticks
+}
+
+func (msg RaceFinished) Subject() string {
+	return "finished"
+}
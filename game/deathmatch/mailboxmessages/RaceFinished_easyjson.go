@@ -0,0 +1,131 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package mailboxmessages
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonCeb7cc45DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(in *jlexer.Lexer, out *RaceFinished) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "rank":
+			out.Rank = int(in.Int())
+		case "time":
+			out.Time = int(in.Int())
+		case "laptimes":
+			if in.IsNull() {
+				in.Skip()
+				out.LapTimes = nil
+			} else {
+				in.Delim('[')
+				if out.LapTimes == nil {
+					if !in.IsDelim(']') {
+						out.LapTimes = make([]int, 0, 8)
+					} else {
+						out.LapTimes = []int{}
+					}
+				} else {
+					out.LapTimes = (out.LapTimes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 int
+					v1 = int(in.Int())
+					out.LapTimes = append(out.LapTimes, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCeb7cc45EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(out *jwriter.Writer, in RaceFinished) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"rank\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Rank))
+	}
+	{
+		const prefix string = ",\"time\":"
+		out.RawString(prefix)
+		out.Int(int(in.Time))
+	}
+	{
+		const prefix string = ",\"laptimes\":"
+		out.RawString(prefix)
+		if in.LapTimes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.LapTimes {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RaceFinished) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCeb7cc45EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RaceFinished) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCeb7cc45EncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RaceFinished) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCeb7cc45DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RaceFinished) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCeb7cc45DecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(l, v)
+}
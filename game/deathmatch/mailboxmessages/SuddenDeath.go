@@ -0,0 +1,8 @@
+package mailboxmessages
+
+// Time is up with a tie for the lead; the next one to take the lead wins
+type SuddenDeath struct{}
+
+func (msg SuddenDeath) Subject() string {
+	return "suddendeath"
+}
@@ -0,0 +1,9 @@
+package mailboxmessages
+
+type YouHaveBeenRestarted struct {
+	Restarts int `json:"restarts"`
+}
+
+func (msg YouHaveBeenRestarted) Subject() string {
+	return "restarted"
+}
@@ -0,0 +1,9 @@
+package mailboxmessages
+
+type YouHaveCollectedPickup struct {
+	Kind string `json:"kind"`
+}
+
+func (msg YouHaveCollectedPickup) Subject() string {
+	return "collectedpickup"
+}
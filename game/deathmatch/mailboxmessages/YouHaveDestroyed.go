@@ -0,0 +1,9 @@
+package mailboxmessages
+
+type YouHaveDestroyed struct {
+	Who string `json:"who"`
+}
+
+func (msg YouHaveDestroyed) Subject() string {
+	return "havedestroyed"
+}
@@ -0,0 +1,13 @@
+package mailboxmessages
+
+import "encoding/json"
+
+type YouHaveHeard struct {
+	From    string          `json:"from"`
+	Channel string          `json:"channel"` // team or radius
+	Payload json.RawMessage `json:"payload"`
+}
+
+func (msg YouHaveHeard) Subject() string {
+	return "heard"
+}
@@ -0,0 +1,101 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package mailboxmessages
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson843dd2dDecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(in *jlexer.Lexer, out *YouHaveHeard) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "from":
+			out.From = string(in.String())
+		case "channel":
+			out.Channel = string(in.String())
+		case "payload":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Payload).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson843dd2dEncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(out *jwriter.Writer, in YouHaveHeard) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.From))
+	}
+	{
+		const prefix string = ",\"channel\":"
+		out.RawString(prefix)
+		out.String(string(in.Channel))
+	}
+	{
+		const prefix string = ",\"payload\":"
+		out.RawString(prefix)
+		out.Raw((in.Payload).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v YouHaveHeard) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson843dd2dEncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v YouHaveHeard) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson843dd2dEncodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *YouHaveHeard) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson843dd2dDecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *YouHaveHeard) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson843dd2dDecodeGithubComBytearenaCoreGameDeathmatchMailboxmessages(l, v)
+}
@@ -0,0 +1,10 @@
+package mailboxmessages
+
+type ZoneControlChanged struct {
+	Holder    string `json:"holder"`
+	Contested bool   `json:"contested"`
+}
+
+func (msg ZoneControlChanged) Subject() string {
+	return "zonecontrolchanged"
+}
@@ -0,0 +1,148 @@
+package deathmatch
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+const perceptionNoiseDefaultSeed = 1
+
+// Perception degradation; read from the "perception" option of the map
+type perceptionNoise struct {
+	positionNoise     float64 // standard deviation of the noise on positions, in m
+	velocityNoise     float64 // standard deviation of the noise on velocities, in m/tick
+	dropout           float64 // probability to miss an item at the edge of the vision; proportional to the distance
+	farDistance       float64 // items further than this are refreshed less often, in m; 0 => no limit
+	farUpdateInterval int     // in ticks
+	seed              int64
+}
+
+// Returns nil if the perception of the map is exact
+func makePerceptionNoise(arenaMap *mapcontainer.MapContainer) *perceptionNoise {
+
+	options, ok := arenaMap.Meta.Options["perception"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	noise := &perceptionNoise{
+		seed:              perceptionNoiseDefaultSeed,
+		farUpdateInterval: 1,
+	}
+
+	if positionNoise, ok := options["positionnoise"].(float64); ok && positionNoise > 0 {
+		noise.positionNoise = positionNoise
+	}
+
+	if velocityNoise, ok := options["velocitynoise"].(float64); ok && velocityNoise > 0 {
+		noise.velocityNoise = velocityNoise
+	}
+
+	if dropout, ok := options["dropout"].(float64); ok && dropout > 0 {
+		noise.dropout = dropout
+	}
+
+	if farDistance, ok := options["fardistance"].(float64); ok && farDistance > 0 {
+		noise.farDistance = farDistance
+	}
+
+	if farUpdateInterval, ok := options["farupdateinterval"].(float64); ok && farUpdateInterval > 1 {
+		noise.farUpdateInterval = int(farUpdateInterval)
+	}
+
+	if seed, ok := options["seed"].(float64); ok {
+		noise.seed = int64(seed)
+	}
+
+	return noise
+}
+
+// Perception is computed concurrently; each agent has its own generator, seeded from the seed of the map
+// and from its id, so that matches are reproducible whatever the order of computation
+func (noise *perceptionNoise) makeRand(entityID ecs.EntityID) *rand.Rand {
+	return rand.New(rand.NewSource(noise.seed + int64(entityID)))
+}
+
+func (noise *perceptionNoise) isFar(item agentPerceptionVisionItem) bool {
+	return noise.farDistance > 0 && noise.farUpdateInterval > 1 && item.Center.Mag() > noise.farDistance
+}
+
+// The vision is built from maps, so its order changes from a run to another;
+// the draws of the generator are applied in a stable order instead
+func sortVision(vision []agentPerceptionVisionItem) {
+	sort.Slice(vision, func(i, j int) bool {
+		a, b := vision[i], vision[j]
+
+		if a.EntityID != b.EntityID {
+			return a.EntityID < b.EntityID
+		}
+
+		if a.SegmentNum != b.SegmentNum {
+			return a.SegmentNum < b.SegmentNum
+		}
+
+		if a.NearEdge.GetX() != b.NearEdge.GetX() {
+			return a.NearEdge.GetX() < b.NearEdge.GetX()
+		}
+
+		return a.NearEdge.GetY() < b.NearEdge.GetY()
+	})
+}
+
+// Applies the noise, the dropout and the update frequency to the vision of the agent
+func (noise *perceptionNoise) degradeVision(ticknum int, entityID ecs.EntityID, perceptionAspect *Perception, vision []agentPerceptionVisionItem) []agentPerceptionVisionItem {
+
+	sortVision(vision)
+
+	rnd := perceptionAspect.rnd
+	visionRadius := perceptionAspect.GetVisionRadius()
+
+	// far items of every agent are not refreshed on the same tick
+	refreshFar := (ticknum+int(entityID))%noise.farUpdateInterval == 0 || perceptionAspect.farVision == nil
+
+	degraded := make([]agentPerceptionVisionItem, 0, len(vision))
+	farVision := make([]agentPerceptionVisionItem, 0)
+
+	for _, item := range vision {
+		isFar := noise.isFar(item)
+		if isFar && !refreshFar {
+			continue
+		}
+
+		if noise.dropout > 0 && visionRadius > 0 && rnd.Float64() < noise.dropout*item.Center.Mag()/visionRadius {
+			continue
+		}
+
+		if noise.positionNoise > 0 {
+			// the whole item is shifted, so that its edges remain consistent
+			offset := vector.MakeVector2(rnd.NormFloat64()*noise.positionNoise, rnd.NormFloat64()*noise.positionNoise)
+			item.NearEdge = item.NearEdge.Add(offset)
+			item.Center = item.Center.Add(offset)
+			item.FarEdge = item.FarEdge.Add(offset)
+		}
+
+		if noise.velocityNoise > 0 {
+			item.Velocity = item.Velocity.Add(vector.MakeVector2(rnd.NormFloat64()*noise.velocityNoise, rnd.NormFloat64()*noise.velocityNoise))
+		}
+
+		if isFar {
+			farVision = append(farVision, item)
+		}
+
+		degraded = append(degraded, item)
+	}
+
+	if refreshFar {
+		perceptionAspect.farVision = farVision
+	} else {
+		// far items as perceived at the last refresh
+		degraded = append(degraded, perceptionAspect.farVision...)
+	}
+
+	return degraded
+}
@@ -0,0 +1,75 @@
+package deathmatch
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/common/types/mapcontainer"
+)
+
+func makeNoisyMap() *mapcontainer.MapContainer {
+	arenaMap := makeBenchmarkMap(40, 4)
+	arenaMap.Meta.Options = map[string]interface{}{
+		"perception": map[string]interface{}{
+			"positionnoise":     0.5,
+			"velocitynoise":     0.1,
+			"dropout":           0.3,
+			"fardistance":       50.0,
+			"farupdateinterval": 3.0,
+			"seed":              42.0,
+		},
+	}
+
+	return arenaMap
+}
+
+// Perceptions of an agent during a match; the vision comes in a different order at every tick,
+// as it does when built from maps
+func runNoisyMatch(shuffleSeed int64) [][]agentPerceptionVisionItem {
+	noise := makePerceptionNoise(makeNoisyMap())
+	entityID := ecs.EntityID(7)
+
+	perceptionAspect := &Perception{
+		visionRadius: 150,
+		rnd:          noise.makeRand(entityID),
+	}
+
+	shuffle := rand.New(rand.NewSource(shuffleSeed))
+	perceptions := make([][]agentPerceptionVisionItem, 0)
+
+	for ticknum := 0; ticknum < 10; ticknum++ {
+		vision := makeBenchmarkVision(50)
+		shuffle.Shuffle(len(vision), func(i, j int) {
+			vision[i], vision[j] = vision[j], vision[i]
+		})
+
+		perceptions = append(perceptions, noise.degradeVision(ticknum, entityID, perceptionAspect, vision))
+	}
+
+	return perceptions
+}
+
+func TestPerceptionNoiseIsReproducible(t *testing.T) {
+	first := runNoisyMatch(1)
+	second := runNoisyMatch(2)
+
+	for ticknum := range first {
+		if !reflect.DeepEqual(first[ticknum], second[ticknum]) {
+			t.Fatalf("tick %d: perceptions differ between two runs of the same map", ticknum)
+		}
+	}
+}
+
+func TestPerceptionNoiseDegrades(t *testing.T) {
+	exact := makeBenchmarkVision(50)
+	sortVision(exact)
+
+	degraded := runNoisyMatch(1)[0]
+
+	if reflect.DeepEqual(exact, degraded) {
+		t.Fatal("perception not degraded")
+	}
+}
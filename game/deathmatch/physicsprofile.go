@@ -0,0 +1,79 @@
+package deathmatch
+
+import (
+	"math"
+
+	"github.com/bytearena/core/common/types/mapcontainer"
+)
+
+const physicsDefaultDensity = 20.0 // agents and projectiles, in kg/m² (physical referential)
+
+const physicsDefaultDrag = 0.015 // m/tick (agent referential)
+
+const physicsMaxIterations = 100
+const physicsMaxSubsteps = 16
+
+// Physics tuning of the arena; read from the "physics" option of the map
+// Defaults are the historical settings of the game
+type physicsProfile struct {
+	velocityIterations int     // Box2D solver; higher improves stability
+	positionIterations int     // Box2D solver; higher improves overlap resolution
+	substeps           int     // world steps per tick
+	drag               float64 // speed lost by the agents on each tick they do not steer, in m/tick (agent referential); 0 => agents coast
+	agentMass          float64 // in kg (physical referential); 0 => derived from the default density
+	wallRestitution    float64 // bounciness of grounds and obstacles, in [0, 1]; 0 => agents stop on walls
+}
+
+func makeDefaultPhysicsProfile() physicsProfile {
+	return physicsProfile{
+		velocityIterations: 4,
+		positionIterations: 2,
+		substeps:           1,
+		drag:               physicsDefaultDrag,
+	}
+}
+
+func makePhysicsProfile(arenaMap *mapcontainer.MapContainer) physicsProfile {
+
+	profile := makeDefaultPhysicsProfile()
+
+	options, ok := arenaMap.Meta.Options["physics"].(map[string]interface{})
+	if !ok {
+		return profile
+	}
+
+	if velocityIterations, ok := options["velocityiterations"].(float64); ok && velocityIterations >= 1 {
+		profile.velocityIterations = int(math.Min(velocityIterations, physicsMaxIterations))
+	}
+
+	if positionIterations, ok := options["positioniterations"].(float64); ok && positionIterations >= 1 {
+		profile.positionIterations = int(math.Min(positionIterations, physicsMaxIterations))
+	}
+
+	if substeps, ok := options["substeps"].(float64); ok && substeps >= 1 {
+		profile.substeps = int(math.Min(substeps, physicsMaxSubsteps))
+	}
+
+	if drag, ok := options["drag"].(float64); ok && drag >= 0 {
+		profile.drag = drag
+	}
+
+	if agentMass, ok := options["agentmass"].(float64); ok && agentMass > 0 {
+		profile.agentMass = agentMass
+	}
+
+	if wallRestitution, ok := options["wallrestitution"].(float64); ok && wallRestitution > 0 {
+		profile.wallRestitution = math.Min(wallRestitution, 1)
+	}
+
+	return profile
+}
+
+// Box2D derives the mass of a body from the density of its fixtures
+func (profile physicsProfile) getAgentDensity(physicalRadius float64) float64 {
+	if profile.agentMass <= 0 || physicalRadius <= 0 {
+		return physicsDefaultDensity
+	}
+
+	return profile.agentMass / (math.Pi * physicalRadius * physicalRadius)
+}
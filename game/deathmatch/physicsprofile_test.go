@@ -0,0 +1,99 @@
+package deathmatch
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+func makePhysicsMap(options map[string]interface{}) *mapcontainer.MapContainer {
+	arenaMap := &mapcontainer.MapContainer{}
+	arenaMap.Meta.Options = map[string]interface{}{
+		"physics": options,
+	}
+
+	return arenaMap
+}
+
+func TestMakePhysicsProfile(t *testing.T) {
+	if profile := makePhysicsProfile(&mapcontainer.MapContainer{}); profile != makeDefaultPhysicsProfile() {
+		t.Errorf("no option: got %+v, expected the defaults", profile)
+	}
+
+	// options are decoded from JSON: numbers are float64
+	profile := makePhysicsProfile(makePhysicsMap(map[string]interface{}{
+		"velocityiterations": 8.0,
+		"positioniterations": 3.0,
+		"substeps":           4.0,
+		"drag":               0.5,
+		"agentmass":          2.0,
+		"wallrestitution":    0.8,
+	}))
+
+	expected := physicsProfile{
+		velocityIterations: 8,
+		positionIterations: 3,
+		substeps:           4,
+		drag:               0.5,
+		agentMass:          2,
+		wallRestitution:    0.8,
+	}
+
+	if profile != expected {
+		t.Errorf("got %+v, expected %+v", profile, expected)
+	}
+
+	// out of range values are clamped or ignored
+	profile = makePhysicsProfile(makePhysicsMap(map[string]interface{}{
+		"velocityiterations": 0.0,
+		"substeps":           1000.0,
+		"drag":               -1.0,
+		"wallrestitution":    3.0,
+		"agentmass":          "heavy",
+	}))
+
+	expected = makeDefaultPhysicsProfile()
+	expected.substeps = physicsMaxSubsteps
+	expected.wallRestitution = 1
+
+	if profile != expected {
+		t.Errorf("got %+v, expected %+v", profile, expected)
+	}
+}
+
+func TestPhysicsProfileDrag(t *testing.T) {
+	if drag := makeDefaultPhysicsProfile().drag; drag != physicsDefaultDrag {
+		t.Errorf("got a default drag of %f, expected %f", drag, physicsDefaultDrag)
+	}
+
+	// ice arena
+	if drag := makePhysicsProfile(makePhysicsMap(map[string]interface{}{"drag": 0.0})).drag; drag != 0 {
+		t.Errorf("got a drag of %f, expected none", drag)
+	}
+
+	velocity := vector.MakeVector2(0.3, 0.4)
+
+	if slowed := applyDrag(velocity, 0.1); math.Abs(slowed.Mag()-0.4) > 0.000001 {
+		t.Errorf("got a speed of %f, expected 0.4", slowed.Mag())
+	}
+
+	if stopped := applyDrag(velocity, 1); !stopped.IsNull() {
+		t.Errorf("got %v, expected the agent to stop", stopped)
+	}
+}
+
+func TestPhysicsProfileAgentDensity(t *testing.T) {
+	if density := makeDefaultPhysicsProfile().getAgentDensity(0.005); density != physicsDefaultDensity {
+		t.Errorf("got %f, expected the default density", density)
+	}
+
+	profile := makeDefaultPhysicsProfile()
+	profile.agentMass = 2
+
+	radius := 0.005
+	if mass := profile.getAgentDensity(radius) * math.Pi * radius * radius; math.Abs(mass-2) > 0.000001 {
+		t.Errorf("got a mass of %f, expected 2", mass)
+	}
+}
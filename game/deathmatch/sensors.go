@@ -0,0 +1,219 @@
+package deathmatch
+
+import (
+	"math"
+
+	"github.com/bytearena/box2d"
+	"github.com/bytearena/ecs"
+
+	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/types/mapcontainer"
+	"github.com/bytearena/core/common/utils/number"
+	"github.com/bytearena/core/common/utils/space"
+	"github.com/bytearena/core/common/utils/trigo"
+	"github.com/bytearena/core/common/utils/vector"
+	"github.com/bytearena/core/game/deathmatch/events"
+)
+
+// Sensors the agents are equipped with, besides vision; read from the "sensors" option of the map
+func getSensorsFromOptions(arenaMap *mapcontainer.MapContainer) map[string]bool {
+	sensors := make(map[string]bool)
+
+	options, ok := arenaMap.Meta.Options["sensors"].([]interface{})
+	if !ok {
+		return sensors
+	}
+
+	for _, option := range options {
+		if kind, ok := option.(string); ok && (kind == sensorKind.Rangefinder || kind == sensorKind.Hearing) {
+			sensors[kind] = true
+		}
+	}
+
+	return sensors
+}
+
+func MakeDefaultRangefinder() *Rangefinder {
+	return &Rangefinder{
+		rays:   9,
+		angle:  number.DegreeToRadian(180),
+		range_: 50,
+	}
+}
+
+func MakeDefaultHearing() *Hearing {
+	return &Hearing{
+		radius: 100,
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Rangefinder: a fan of raycasts, like a lidar
+///////////////////////////////////////////////////////////////////////////////
+
+func computeAgentRangefinder(game *DeathmatchGame, entityID ecs.EntityID, physicalAspect *PhysicalBody, rangefinder *Rangefinder) []agentPerceptionRay {
+
+	rays := make([]agentPerceptionRay, 0, rangefinder.rays)
+
+	position := physicalAspect.GetPosition()
+	orientation := physicalAspect.GetOrientation()
+	from := game.spaces.AgentToPhysical(space.MakeAgentVector2FromVector2(position))
+
+	// rays are evenly distributed over the angle, centered on the heading
+	step := 0.0
+	if rangefinder.rays > 1 {
+		step = rangefinder.angle / float64(rangefinder.rays-1)
+	}
+
+	for i := 0; i < rangefinder.rays; i++ {
+		relativeAngle := 0.0
+		if rangefinder.rays > 1 {
+			relativeAngle = -rangefinder.angle/2 + float64(i)*step
+		}
+
+		direction := vector.MakeVector2(1, 1).SetMag(rangefinder.range_).SetAngle(orientation + relativeAngle)
+		to := game.spaces.AgentToPhysical(space.MakeAgentVector2FromVector2(position.Add(direction)))
+
+		closestFraction := math.MaxFloat64
+		var closestDescriptor *commontypes.PhysicalBodyDescriptor
+
+		game.PhysicalWorld.RayCast(func(fixture *box2d.B2Fixture, point box2d.B2Vec2, normal box2d.B2Vec2, fraction float64) float64 {
+
+			if fixture.IsSensor() {
+				return -1 // ignore this fixture
+			}
+
+			descriptor, ok := fixture.GetBody().GetUserData().(commontypes.PhysicalBodyDescriptor)
+			if !ok || descriptor.ID == entityID || descriptor.Type == commontypes.PhysicalBodyDescriptorType.Ground {
+				return -1 // ignore this fixture
+			}
+
+			if fraction < closestFraction {
+				closestFraction = fraction
+				closestDescriptor = &descriptor
+			}
+
+			return fraction // clip the ray to this point
+		}, from.ToB2Vec2(), to.ToB2Vec2())
+
+		ray := agentPerceptionRay{
+			Angle:    relativeAngle,
+			Distance: rangefinder.range_,
+		}
+
+		if closestDescriptor != nil {
+			ray.Distance = closestFraction * rangefinder.range_
+			ray.Tag = getVisionItemTagForDescriptor(*closestDescriptor)
+		}
+
+		rays = append(rays, ray)
+	}
+
+	return rays
+}
+
+func getVisionItemTagForDescriptor(descriptor commontypes.PhysicalBodyDescriptor) string {
+	switch descriptor.Type {
+	case commontypes.PhysicalBodyDescriptorType.Agent:
+		return agentPerceptionVisionItemTag.Agent
+	case commontypes.PhysicalBodyDescriptorType.Projectile:
+		return agentPerceptionVisionItemTag.Projectile
+	case commontypes.PhysicalBodyDescriptorType.Pickup:
+		return agentPerceptionVisionItemTag.Pickup
+	case commontypes.PhysicalBodyDescriptorType.Flag:
+		return agentPerceptionVisionItemTag.Flag
+	default:
+		return agentPerceptionVisionItemTag.Obstacle
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Hearing: shots and impacts of the tick, heard around
+///////////////////////////////////////////////////////////////////////////////
+
+func computeAgentHearing(game *DeathmatchGame, entityID ecs.EntityID, physicalAspect *PhysicalBody, hearing *Hearing) []agentPerceptionSound {
+
+	heard := make([]agentPerceptionSound, 0)
+
+	position := physicalAspect.GetPosition()
+	orientation := physicalAspect.GetOrientation()
+
+	for _, s := range game.sounds {
+		if s.emitter == entityID {
+			// one does not hear itself
+			continue
+		}
+
+		relative := s.position.Sub(position)
+		distance := relative.Mag()
+		if distance > hearing.radius {
+			continue
+		}
+
+		direction := 0.0
+		if distance > 0 {
+			direction = trigo.FullCircleAngleToSignedHalfCircleAngle(math.Mod(relative.Angle()-orientation+pi2, pi2))
+		}
+
+		heard = append(heard, agentPerceptionSound{
+			Kind:      s.kind,
+			Direction: direction,
+			Distance:  distance,
+		})
+	}
+
+	return heard
+}
+
+func (game *DeathmatchGame) emitSound(kind string, emitter ecs.EntityID, source ecs.EntityID) {
+	qr := game.getEntity(source, game.physicalBodyComponent)
+	if qr == nil {
+		return
+	}
+
+	game.sounds = append(game.sounds, sound{
+		kind:     kind,
+		emitter:  emitter,
+		position: qr.Components[game.physicalBodyComponent].(*PhysicalBody).GetPosition(),
+	})
+}
+
+func (game *DeathmatchGame) onEntityHitSound(e events.EntityHit) {
+
+	// The shot is heard where the shooter stands, the impact where the hit entity stands
+	shooterID := e.HitBy
+	if ownedQuery := game.getEntity(e.HitBy, game.ownedComponent); ownedQuery != nil {
+		shooterID = ownedQuery.Components[game.ownedComponent].(*Owned).GetOwner()
+	}
+
+	game.emitSound(soundKind.Shot, shooterID, shooterID)
+	game.emitSound(soundKind.Impact, e.Entity, e.Entity)
+}
+
+func (game *DeathmatchGame) onEntityFraggedSound(e events.EntityFragged) {
+	game.emitSound(soundKind.Frag, e.Entity, e.Entity)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Welcome specs
+///////////////////////////////////////////////////////////////////////////////
+
+func makeSensorsSpecs(perceptionAspect *Perception) map[string]interface{} {
+	sensors := make(map[string]interface{})
+
+	if rangefinder := perceptionAspect.GetRangefinder(); rangefinder != nil {
+		sensors[sensorKind.Rangefinder] = rangefinderSpecs{
+			Rays:  rangefinder.rays,
+			Angle: commontypes.Angle(rangefinder.angle),
+			Range: rangefinder.range_,
+		}
+	}
+
+	if hearing := perceptionAspect.GetHearing(); hearing != nil {
+		sensors[sensorKind.Hearing] = hearingSpecs{
+			Radius: hearing.radius,
+		}
+	}
+
+	return sensors
+}
@@ -0,0 +1,184 @@
+package deathmatch
+
+import (
+	"github.com/bytearena/box2d"
+	"github.com/bytearena/ecs"
+	"github.com/dhconnelly/rtreego"
+
+	commontypes "github.com/bytearena/core/common/types"
+	"github.com/bytearena/core/common/utils/space"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+const spatialIndexMinChildren = 4
+const spatialIndexMaxChildren = 16
+const spatialIndexPadding = 0.01 // rtreego refuses flat rectangles (horizontal or vertical segments)
+
+// Spatial index of the perception; agent referential
+// Static obstacle segments never change: they are indexed once
+// Everything else (agents, projectiles, pickups, flags, kinematic and destructible obstacles) is indexed every tick
+type spatialIndex struct {
+	static  *rtreego.Rtree
+	dynamic *rtreego.Rtree
+
+	staticEntities map[ecs.EntityID]bool
+}
+
+type staticSegment struct {
+	entityID   ecs.EntityID
+	segmentNum int
+	pointA     vector.Vector2 // agent referential
+	pointB     vector.Vector2 // agent referential
+	bounds     *rtreego.Rect
+}
+
+func (s *staticSegment) Bounds() *rtreego.Rect {
+	return s.bounds
+}
+
+type dynamicBody struct {
+	descriptor commontypes.PhysicalBodyDescriptor
+	bounds     *rtreego.Rect
+}
+
+func (b *dynamicBody) Bounds() *rtreego.Rect {
+	return b.bounds
+}
+
+func makeSpatialIndexRect(aabb vector.AABB) *rtreego.Rect {
+	lowerX, lowerY := aabb.LowerBound.Get()
+	upperX, upperY := aabb.UpperBound.Get()
+
+	rect, _ := rtreego.NewRect(
+		rtreego.Point{lowerX - spatialIndexPadding, lowerY - spatialIndexPadding},
+		[]float64{upperX - lowerX + 2*spatialIndexPadding, upperY - lowerY + 2*spatialIndexPadding},
+	)
+
+	return rect
+}
+
+func isStaticObstacle(game *DeathmatchGame, entityID ecs.EntityID, physicalAspect *PhysicalBody) bool {
+
+	descriptor, ok := physicalAspect.GetBody().GetUserData().(commontypes.PhysicalBodyDescriptor)
+	if !ok {
+		return false
+	}
+
+	if descriptor.Type != commontypes.PhysicalBodyDescriptorType.Ground && descriptor.Type != commontypes.PhysicalBodyDescriptorType.Obstacle {
+		return false
+	}
+
+	if physicalAspect.GetBody().GetType() != box2d.B2BodyType.B2_staticBody {
+		return false
+	}
+
+	// destructible obstacles vanish when destroyed
+	return game.getEntity(entityID, game.destructibleComponent) == nil
+}
+
+// Indexes the segments of the static obstacles; called once the map is loaded
+func newSpatialIndex(game *DeathmatchGame) *spatialIndex {
+
+	index := &spatialIndex{
+		static:         rtreego.NewTree(2, spatialIndexMinChildren, spatialIndexMaxChildren),
+		dynamic:        rtreego.NewTree(2, spatialIndexMinChildren, spatialIndexMaxChildren),
+		staticEntities: make(map[ecs.EntityID]bool),
+	}
+
+	for _, entityresult := range game.physicalView.Get() {
+		entityID := entityresult.Entity.GetID()
+		physicalAspect := entityresult.Components[game.physicalBodyComponent].(*PhysicalBody)
+
+		if !isStaticObstacle(game, entityID, physicalAspect) {
+			continue
+		}
+
+		index.staticEntities[entityID] = true
+
+		segmentNumber := -1
+		for fixture := physicalAspect.GetBody().GetFixtureList(); fixture != nil; fixture = fixture.GetNext() {
+			b2edge, ok := fixture.GetShape().(*box2d.B2EdgeShape)
+			if !ok {
+				continue
+			}
+
+			segmentNumber++ // starts at 0, as in the vision
+
+			pointA := game.spaces.PhysicalToAgent(space.PhysicalFromB2Vec2(physicalAspect.GetBody().GetWorldPoint(b2edge.M_vertex1))).Vector2()
+			pointB := game.spaces.PhysicalToAgent(space.PhysicalFromB2Vec2(physicalAspect.GetBody().GetWorldPoint(b2edge.M_vertex2))).Vector2()
+
+			index.static.Insert(&staticSegment{
+				entityID:   entityID,
+				segmentNum: segmentNumber,
+				pointA:     pointA,
+				pointB:     pointB,
+				bounds:     makeSpatialIndexRect(vector.GetAABBForPointList(pointA, pointB)),
+			})
+		}
+	}
+
+	return index
+}
+
+// Indexes the bodies that may have moved, appeared or vanished since the previous tick
+func (index *spatialIndex) refresh(game *DeathmatchGame) {
+
+	index.dynamic = rtreego.NewTree(2, spatialIndexMinChildren, spatialIndexMaxChildren)
+
+	for _, entityresult := range game.physicalView.Get() {
+		entityID := entityresult.Entity.GetID()
+		if index.staticEntities[entityID] {
+			continue
+		}
+
+		body := entityresult.Components[game.physicalBodyComponent].(*PhysicalBody).GetBody()
+
+		descriptor, ok := body.GetUserData().(commontypes.PhysicalBodyDescriptor)
+		if !ok {
+			continue
+		}
+
+		points := make([]vector.Vector2, 0)
+		for fixture := body.GetFixtureList(); fixture != nil; fixture = fixture.GetNext() {
+			aabb := fixture.GetAABB(0)
+			points = append(points,
+				game.spaces.PhysicalToAgent(space.PhysicalFromB2Vec2(aabb.LowerBound)).Vector2(),
+				game.spaces.PhysicalToAgent(space.PhysicalFromB2Vec2(aabb.UpperBound)).Vector2(),
+			)
+		}
+
+		if len(points) == 0 {
+			// no fixture (destroyed obstacle): nothing to see
+			continue
+		}
+
+		index.dynamic.Insert(&dynamicBody{
+			descriptor: descriptor,
+			bounds:     makeSpatialIndexRect(vector.GetAABBForPointList(points...)),
+		})
+	}
+}
+
+// Static obstacle segments overlapping the area; agent referential
+func (index *spatialIndex) searchStaticSegments(aabb vector.AABB) []*staticSegment {
+	found := index.static.SearchIntersect(makeSpatialIndexRect(aabb))
+
+	segments := make([]*staticSegment, len(found))
+	for i, spatial := range found {
+		segments[i] = spatial.(*staticSegment)
+	}
+
+	return segments
+}
+
+// Descriptors of the other bodies overlapping the area; agent referential
+func (index *spatialIndex) searchDynamicBodies(aabb vector.AABB) []commontypes.PhysicalBodyDescriptor {
+	found := index.dynamic.SearchIntersect(makeSpatialIndexRect(aabb))
+
+	descriptors := make([]commontypes.PhysicalBodyDescriptor, len(found))
+	for i, spatial := range found {
+		descriptors[i] = spatial.(*dynamicBody).descriptor
+	}
+
+	return descriptors
+}
@@ -0,0 +1,154 @@
+package deathmatch
+
+import (
+	"math"
+
+	"github.com/bytearena/ecs"
+
+	"github.com/bytearena/core/common/utils/space"
+	"github.com/bytearena/core/common/utils/vector"
+)
+
+var weaponKind = struct {
+	Gun     string
+	Shotgun string
+	Rocket  string
+	Laser   string
+}{
+	Gun:     "gun",
+	Shotgun: "shotgun",
+	Rocket:  "rocket",
+	Laser:   "laser",
+}
+
+type Weapon struct {
+	Kind string
+
+	ShootCost     float64 // Const; Energy consumed by a shot
+	ShootCooldown int     // Const; number of ticks to wait after a shot with this weapon
+
+	ProjectileSpeed  float64 // Const; expressed in m/tick; unused by hitscan weapons
+	ProjectileDamage float64 // Const; amount of life consumed on impact
+	ProjectileRange  float64 // Const, in meter
+	ProjectileRadius float64 // Const, in meter
+
+	Pellets int     // Const; number of projectiles fired per shot
+	Spread  float64 // Const; angle covered by the pellets, in radian
+
+	SplashRadius float64 // Const; radius of the explosion on impact, in meter
+	SplashDamage float64 // Const; damage at the center of the explosion, fading with distance
+}
+
+func (weapon Weapon) IsHitscan() bool {
+	return weapon.Kind == weaponKind.Laser
+}
+
+func MakeDefaultWeapons() map[string]*Weapon {
+	return map[string]*Weapon{
+		weaponKind.Gun: &Weapon{
+			Kind:             weaponKind.Gun,
+			ShootCost:        200,
+			ShootCooldown:    3,
+			ProjectileSpeed:  15,
+			ProjectileDamage: 400,
+			ProjectileRange:  1200,
+			ProjectileRadius: 0.3,
+			Pellets:          1,
+		},
+		weaponKind.Shotgun: &Weapon{
+			Kind:             weaponKind.Shotgun,
+			ShootCost:        350,
+			ShootCooldown:    12,
+			ProjectileSpeed:  12,
+			ProjectileDamage: 150,
+			ProjectileRange:  120,
+			ProjectileRadius: 0.2,
+			Pellets:          5,
+			Spread:           math.Pi / 8,
+		},
+		weaponKind.Rocket: &Weapon{
+			Kind:             weaponKind.Rocket,
+			ShootCost:        600,
+			ShootCooldown:    30,
+			ProjectileSpeed:  6,
+			ProjectileDamage: 600,
+			ProjectileRange:  600,
+			ProjectileRadius: 0.5,
+			Pellets:          1,
+			SplashRadius:     8,
+			SplashDamage:     400,
+		},
+		weaponKind.Laser: &Weapon{
+			Kind:             weaponKind.Laser,
+			ShootCost:        300,
+			ShootCooldown:    8,
+			ProjectileDamage: 250,
+			ProjectileRange:  80,
+		},
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Impacts that do not come from a collision (hitscan, splash)
+///////////////////////////////////////////////////////////////////////////////
+
+type impact struct {
+	entityID   ecs.EntityID
+	impactorID ecs.EntityID
+	damage     float64
+	comingFrom float64 // absolute azimuth in radian
+}
+
+type explosion struct {
+	entityID    ecs.EntityID // the projectile that exploded
+	ownerID     ecs.EntityID
+	directHitID ecs.EntityID // what the projectile hit; takes the projectile damage, not the splash
+	point       vector.Vector2
+	radius      float64
+	damage      float64
+}
+
+type beam struct {
+	from space.PhysicalVector2
+	to   space.PhysicalVector2
+}
+
+func makeWeaponSpecs(weapon *Weapon) interface{} {
+	switch weapon.Kind {
+	case weaponKind.Shotgun:
+		return shotgunSpecs{
+			ShootCost:        weapon.ShootCost,
+			ShootCooldown:    weapon.ShootCooldown,
+			ProjectileSpeed:  weapon.ProjectileSpeed,
+			ProjectileDamage: weapon.ProjectileDamage,
+			ProjectileRange:  weapon.ProjectileRange,
+			Pellets:          weapon.Pellets,
+			Spread:           weapon.Spread,
+		}
+	case weaponKind.Rocket:
+		return rocketSpecs{
+			ShootCost:        weapon.ShootCost,
+			ShootCooldown:    weapon.ShootCooldown,
+			ProjectileSpeed:  weapon.ProjectileSpeed,
+			ProjectileDamage: weapon.ProjectileDamage,
+			ProjectileRange:  weapon.ProjectileRange,
+			SplashRadius:     weapon.SplashRadius,
+			SplashDamage:     weapon.SplashDamage,
+		}
+	case weaponKind.Laser:
+		return laserSpecs{
+			ShootCost:     weapon.ShootCost,
+			ShootCooldown: weapon.ShootCooldown,
+			Damage:        weapon.ProjectileDamage,
+			Range:         weapon.ProjectileRange,
+		}
+	default:
+		return gunSpecs{
+			ShootCost:        weapon.ShootCost,
+			ShootCooldown:    weapon.ShootCooldown,
+			ProjectileSpeed:  weapon.ProjectileSpeed,
+			ProjectileDamage: weapon.ProjectileDamage,
+			ProjectileRange:  weapon.ProjectileRange,
+		}
+	}
+}